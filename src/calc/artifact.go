@@ -0,0 +1,168 @@
+package calc
+
+import (
+	"fmt"
+
+	"github.com/utkarsh5026/Genka/src/client"
+	"github.com/utkarsh5026/Genka/src/data"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+// MainStat is the main stat of an artifact at a given level.
+type MainStat struct {
+	Prop  mapping.FightProp
+	Value float64
+}
+
+type levelKey struct {
+	rank  int
+	level int
+}
+
+// ArtifactCalculator resolves artifact stats from ReliquaryExcelConfigData,
+// ReliquaryMainPropExcelConfigData and ReliquaryLevelExcelConfigData.
+//
+// Levels are one-based like in Enka's reliquary data: level 1 is +0 and level 21 is +20.
+type ArtifactCalculator struct {
+	reliquaries map[int]data.ReliquaryConfig
+	mainProps   map[int]data.ReliquaryMainProp
+	levels      map[levelKey]map[mapping.FightProp]float64
+	maxLevels   map[int]int
+}
+
+// NewArtifactCalculator indexes the parsed Excel records for lookups.
+func NewArtifactCalculator(reliquaries []data.ReliquaryConfig, mainProps []data.ReliquaryMainProp, levels []data.ReliquaryLevel) *ArtifactCalculator {
+	byID := make(map[int]data.ReliquaryConfig, len(reliquaries))
+	for _, reliquary := range reliquaries {
+		byID[reliquary.ID] = reliquary
+	}
+
+	byMainPropID := make(map[int]data.ReliquaryMainProp, len(mainProps))
+	for _, mainProp := range mainProps {
+		byMainPropID[mainProp.ID] = mainProp
+	}
+
+	byRankLevel := make(map[levelKey]map[mapping.FightProp]float64, len(levels))
+	maxLevels := make(map[int]int)
+	for _, level := range levels {
+		// the first records only hold the exp curve and have no rank
+		if level.Rank == 0 {
+			continue
+		}
+		byRankLevel[levelKey{rank: level.Rank, level: level.Level}] = sumProps(level.AddProps)
+		if level.Level > maxLevels[level.Rank] {
+			maxLevels[level.Rank] = level.Level
+		}
+	}
+
+	return &ArtifactCalculator{
+		reliquaries: byID,
+		mainProps:   byMainPropID,
+		levels:      byRankLevel,
+		maxLevels:   maxLevels,
+	}
+}
+
+// LoadArtifactCalculator loads the data files an ArtifactCalculator needs,
+// downloading any that are missing.
+func LoadArtifactCalculator(rl *data.ResourceLoader) (*ArtifactCalculator, error) {
+	reliquaries, err := data.LoadExcel(rl, data.ArtifactDataFile, data.ParseReliquaryConfigs)
+	if err != nil {
+		return nil, err
+	}
+	mainProps, err := data.LoadExcel(rl, data.ArtifactMainPropFile, data.ParseReliquaryMainProps)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := data.LoadExcel(rl, data.ArtifactMainStatFile, data.ParseReliquaryLevels)
+	if err != nil {
+		return nil, err
+	}
+	return NewArtifactCalculator(reliquaries, mainProps, levels), nil
+}
+
+// MaxLevel returns the highest level of an artifact rarity, e.g. 21 (+20) for 5 stars.
+// It returns 0 for an unknown rarity.
+func (c *ArtifactCalculator) MaxLevel(rankLevel int) int {
+	return c.maxLevels[rankLevel]
+}
+
+// MainStatProp returns the stat a mainPropId stands for, e.g. 14001 is FIGHT_PROP_HP.
+func (c *ArtifactCalculator) MainStatProp(mainPropID int) (mapping.FightProp, error) {
+	mainProp, ok := c.mainProps[mainPropID]
+	if !ok {
+		return "", fmt.Errorf("%w: main prop %d", ErrUnknownItem, mainPropID)
+	}
+	return mainProp.PropType, nil
+}
+
+// MainStat returns the main stat of an artifact.
+//
+// Parameters:
+//   - mainPropID: The mainPropId of the artifact
+//   - rankLevel: The rarity of the artifact (1-5)
+//   - level: The one-based level, so 1 for +0 and 21 for +20
+//
+// Returns:
+//   - MainStat: The main stat and its value at the level
+//   - error: ErrUnknownItem or ErrInvalidLevel
+func (c *ArtifactCalculator) MainStat(mainPropID, rankLevel, level int) (MainStat, error) {
+	prop, err := c.MainStatProp(mainPropID)
+	if err != nil {
+		return MainStat{}, err
+	}
+
+	values, ok := c.levels[levelKey{rank: rankLevel, level: level}]
+	if !ok {
+		return MainStat{}, fmt.Errorf("%w: level %d for rarity %d", ErrInvalidLevel, level, rankLevel)
+	}
+	value, ok := values[prop]
+	if !ok {
+		return MainStat{}, fmt.Errorf("%w: %s has no value at level %d for rarity %d", ErrUnknownItem, prop, level, rankLevel)
+	}
+	return MainStat{Prop: prop, Value: value}, nil
+}
+
+// MainStatCurve returns the main stat at every level from 1 (+0) to MaxLevel.
+func (c *ArtifactCalculator) MainStatCurve(mainPropID, rankLevel int) ([]MainStat, error) {
+	maxLevel := c.MaxLevel(rankLevel)
+	if maxLevel == 0 {
+		return nil, fmt.Errorf("%w: rarity %d", ErrInvalidLevel, rankLevel)
+	}
+
+	curve := make([]MainStat, 0, maxLevel)
+	for level := 1; level <= maxLevel; level++ {
+		stat, err := c.MainStat(mainPropID, rankLevel, level)
+		if err != nil {
+			return nil, err
+		}
+		curve = append(curve, stat)
+	}
+	return curve, nil
+}
+
+// RankLevel returns the rarity of an artifact item. It reads the flat block
+// when present and falls back to ReliquaryExcelConfigData otherwise,
+// as saved builds do not always carry a flat block.
+func (c *ArtifactCalculator) RankLevel(equip client.Equipment) (int, error) {
+	if equip.Flat.RankLevel > 0 {
+		return equip.Flat.RankLevel, nil
+	}
+	reliquary, ok := c.reliquaries[equip.ItemID]
+	if !ok {
+		return 0, fmt.Errorf("%w: artifact %d", ErrUnknownItem, equip.ItemID)
+	}
+	return reliquary.RankLevel, nil
+}
+
+// EquipmentMainStat returns the main stat of an artifact from an Enka equipList entry.
+func (c *ArtifactCalculator) EquipmentMainStat(equip client.Equipment) (MainStat, error) {
+	if !equip.IsArtifact() {
+		return MainStat{}, fmt.Errorf("%w: item %d is not an artifact", ErrUnknownItem, equip.ItemID)
+	}
+	rank, err := c.RankLevel(equip)
+	if err != nil {
+		return MainStat{}, err
+	}
+	return c.MainStat(equip.Reliquary.MainPropID, rank, equip.Reliquary.Level)
+}
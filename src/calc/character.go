@@ -0,0 +1,132 @@
+package calc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/utkarsh5026/Genka/src/data"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+// CharacterStats are the base stats of a character, without weapon and artifacts.
+type CharacterStats struct {
+	HP  float64
+	ATK float64
+	DEF float64
+	// AscensionProp is the bonus stat gained from ascending, e.g. FIGHT_PROP_CRITICAL_HURT.
+	// AscensionValue is 0 until the ascension phase that first grants it.
+	AscensionProp  mapping.FightProp
+	AscensionValue float64
+}
+
+// CharacterCalculator computes base stats from AvatarExcelConfigData,
+// AvatarCurveExcelConfigData and AvatarPromoteExcelConfigData.
+type CharacterCalculator struct {
+	avatars  map[int]data.AvatarConfig
+	curves   curveTable
+	promotes map[int][]data.AvatarPromote
+}
+
+// NewCharacterCalculator indexes the parsed Excel records for lookups.
+func NewCharacterCalculator(avatars []data.AvatarConfig, curves []data.AvatarCurve, promotes []data.AvatarPromote) *CharacterCalculator {
+	byID := make(map[int]data.AvatarConfig, len(avatars))
+	for _, avatar := range avatars {
+		byID[avatar.ID] = avatar
+	}
+
+	levels := make(map[int][]data.CurveInfo, len(curves))
+	for _, curve := range curves {
+		levels[curve.Level] = curve.CurveInfos
+	}
+
+	byPromoteID := make(map[int][]data.AvatarPromote)
+	for _, promote := range promotes {
+		byPromoteID[promote.AvatarPromoteID] = append(byPromoteID[promote.AvatarPromoteID], promote)
+	}
+	for _, phases := range byPromoteID {
+		sort.Slice(phases, func(i, j int) bool {
+			return phases[i].PromoteLevel < phases[j].PromoteLevel
+		})
+	}
+
+	return &CharacterCalculator{
+		avatars:  byID,
+		curves:   newCurveTable(levels),
+		promotes: byPromoteID,
+	}
+}
+
+// LoadCharacterCalculator loads the data files a CharacterCalculator needs,
+// downloading any that are missing.
+func LoadCharacterCalculator(rl *data.ResourceLoader) (*CharacterCalculator, error) {
+	avatars, err := data.LoadExcel(rl, data.CharacterDataFile, data.ParseAvatarConfigs)
+	if err != nil {
+		return nil, err
+	}
+	curves, err := data.LoadExcel(rl, data.CharacterStatCurveFile, data.ParseAvatarCurves)
+	if err != nil {
+		return nil, err
+	}
+	promotes, err := data.LoadExcel(rl, data.CharacterAscensionFile, data.ParseAvatarPromotes)
+	if err != nil {
+		return nil, err
+	}
+	return NewCharacterCalculator(avatars, curves, promotes), nil
+}
+
+// BaseStats returns the base HP, ATK and DEF and the ascension bonus stat
+// of a character.
+//
+// Parameters:
+//   - avatarID: The ID of the character, e.g. 10000060
+//   - level: The character level (1-90)
+//   - ascension: The ascension phase (0-6); the level must be reachable in it
+//
+// Returns:
+//   - CharacterStats: The computed base stats
+//   - error: ErrUnknownItem, ErrInvalidLevel or an error about missing curve data
+func (c *CharacterCalculator) BaseStats(avatarID, level, ascension int) (CharacterStats, error) {
+	var stats CharacterStats
+
+	avatar, ok := c.avatars[avatarID]
+	if !ok {
+		return stats, fmt.Errorf("%w: avatar %d", ErrUnknownItem, avatarID)
+	}
+
+	phases := c.promotes[avatar.AvatarPromoteID]
+	maxLevels := make([]int, len(phases))
+	for i, phase := range phases {
+		maxLevels[i] = phase.UnlockMaxLevel
+	}
+	if err := checkLevel(level, ascension, maxLevels); err != nil {
+		return stats, err
+	}
+
+	bases := map[mapping.FightProp]float64{
+		mapping.FIGHT_PROP_BASE_HP:      avatar.HpBase,
+		mapping.FIGHT_PROP_BASE_ATTACK:  avatar.AttackBase,
+		mapping.FIGHT_PROP_BASE_DEFENSE: avatar.DefenseBase,
+	}
+	values := make(map[mapping.FightProp]float64, len(bases))
+	for _, grow := range avatar.PropGrowCurves {
+		value, err := c.curves.apply(grow.GrowCurve, level, bases[grow.Type])
+		if err != nil {
+			return stats, err
+		}
+		values[grow.Type] = value
+	}
+
+	for prop, value := range sumProps(phases[ascension].AddProps) {
+		if _, ok := bases[prop]; ok {
+			values[prop] += value
+			continue
+		}
+		stats.AscensionProp = prop
+		stats.AscensionValue = value
+	}
+
+	stats.HP = values[mapping.FIGHT_PROP_BASE_HP]
+	stats.ATK = values[mapping.FIGHT_PROP_BASE_ATTACK]
+	stats.DEF = values[mapping.FIGHT_PROP_BASE_DEFENSE]
+	return stats, nil
+}
@@ -0,0 +1,72 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/utkarsh5026/Genka/src/data"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+var (
+	ErrUnknownItem    = errors.New("unknown item")
+	ErrInvalidLevel   = errors.New("invalid level")
+	ErrUnknownCurve   = errors.New("unknown grow curve")
+	ErrUnknownPromote = errors.New("unknown promote level")
+	ErrUnknownAffix   = errors.New("unknown affix")
+)
+
+// curveTable indexes grow curve multipliers by level and curve type
+type curveTable map[int]map[string]data.CurveInfo
+
+func newCurveTable(levels map[int][]data.CurveInfo) curveTable {
+	table := make(curveTable, len(levels))
+	for level, infos := range levels {
+		byType := make(map[string]data.CurveInfo, len(infos))
+		for _, info := range infos {
+			byType[info.Type] = info
+		}
+		table[level] = byType
+	}
+	return table
+}
+
+// apply scales base by the multiplier of curve at level.
+// Every stat curve of the game uses ARITH_MULTI.
+func (t curveTable) apply(curve string, level int, base float64) (float64, error) {
+	info, ok := t[level][curve]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s at level %d", ErrUnknownCurve, curve, level)
+	}
+	return base * info.Value, nil
+}
+
+// sumProps adds up the values of props grouped by type, skipping placeholder entries
+func sumProps(props []data.PropEntry) map[mapping.FightProp]float64 {
+	sums := make(map[mapping.FightProp]float64)
+	for _, prop := range props {
+		if prop.PropType == "" {
+			continue
+		}
+		sums[prop.PropType] += prop.Value
+	}
+	return sums
+}
+
+// checkLevel validates level against the level caps of the ascension phases.
+// maxLevels holds the unlockMaxLevel of each phase, indexed by promote level.
+func checkLevel(level, promoteLevel int, maxLevels []int) error {
+	if promoteLevel < 0 || promoteLevel >= len(maxLevels) {
+		return fmt.Errorf("%w: %d", ErrUnknownPromote, promoteLevel)
+	}
+
+	minLevel := 1
+	if promoteLevel > 0 {
+		minLevel = maxLevels[promoteLevel-1]
+	}
+	if level < minLevel || level > maxLevels[promoteLevel] {
+		return fmt.Errorf("%w: level %d is outside %d-%d for ascension %d",
+			ErrInvalidLevel, level, minLevel, maxLevels[promoteLevel], promoteLevel)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package calc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/utkarsh5026/Genka/src/client"
+	"github.com/utkarsh5026/Genka/src/data"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+// RollTier is the quality of a single substat roll.
+type RollTier int
+
+const (
+	TierLow RollTier = iota
+	TierMid
+	TierHigh
+	TierMax
+)
+
+func (t RollTier) String() string {
+	switch t {
+	case TierLow:
+		return "low"
+	case TierMid:
+		return "mid"
+	case TierHigh:
+		return "high"
+	case TierMax:
+		return "max"
+	}
+	return "unknown"
+}
+
+// SubstatRoll is one entry of an artifact's appendPropIdList.
+type SubstatRoll struct {
+	AffixID int
+	Tier    RollTier
+	Value   float64
+	// Initial is true for the rolls the artifact dropped with, false for upgrades
+	Initial bool
+}
+
+// Substat groups the rolls that went into a single substat.
+type Substat struct {
+	Prop  mapping.FightProp
+	Value float64
+	Rolls []SubstatRoll
+}
+
+// Upgrades returns the number of rolls added to the substat by leveling the artifact.
+func (s Substat) Upgrades() int {
+	upgrades := 0
+	for _, roll := range s.Rolls {
+		if !roll.Initial {
+			upgrades++
+		}
+	}
+	return upgrades
+}
+
+// SubstatBreakdown is the decomposition of an artifact's appendPropIdList.
+type SubstatBreakdown struct {
+	// Substats are in the order they were added to the artifact
+	Substats []Substat
+	// InitialRolls is the number of substats the artifact dropped with (3 or 4 for 5 stars)
+	InitialRolls int
+	// UpgradeRolls is the number of rolls gained every 4 levels
+	UpgradeRolls int
+}
+
+type affixTier struct {
+	affix data.ReliquaryAffix
+	tier  RollTier
+}
+
+// SubstatDecoder breaks artifact substats down into their individual rolls
+// using ReliquaryAffixExcelConfigData.
+type SubstatDecoder struct {
+	affixes map[int]affixTier
+}
+
+// NewSubstatDecoder indexes the substat rolls and ranks them into tiers.
+// Within a depot, the rolls of a stat are ordered by value, the highest being TierMax.
+func NewSubstatDecoder(affixes []data.ReliquaryAffix) *SubstatDecoder {
+	type depotProp struct {
+		depotID int
+		prop    mapping.FightProp
+	}
+	groups := make(map[depotProp][]data.ReliquaryAffix)
+	for _, affix := range affixes {
+		key := depotProp{depotID: affix.DepotID, prop: affix.PropType}
+		groups[key] = append(groups[key], affix)
+	}
+
+	tiers := make(map[int]affixTier, len(affixes))
+	for _, group := range groups {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].PropValue < group[j].PropValue
+		})
+		// depots with fewer than four tiers lose the lowest ones
+		offset := int(TierMax) + 1 - len(group)
+		for i, affix := range group {
+			tier := RollTier(i + offset)
+			if tier < TierLow {
+				tier = TierLow
+			}
+			tiers[affix.ID] = affixTier{affix: affix, tier: tier}
+		}
+	}
+	return &SubstatDecoder{affixes: tiers}
+}
+
+// LoadSubstatDecoder loads ReliquaryAffixExcelConfigData, downloading it if missing.
+func LoadSubstatDecoder(rl *data.ResourceLoader) (*SubstatDecoder, error) {
+	affixes, err := data.LoadExcel(rl, data.ArtifactSubStatFile, data.ParseReliquaryAffixes)
+	if err != nil {
+		return nil, err
+	}
+	return NewSubstatDecoder(affixes), nil
+}
+
+// Decode groups the rolls of an artifact per substat.
+//
+// An artifact gains one roll every 4 levels, so the last (level-1)/4 entries
+// of appendPropIDs are upgrades and the ones before them are initial substats.
+//
+// Parameters:
+//   - appendPropIDs: The appendPropIdList of the artifact
+//   - level: The one-based level of the artifact, 21 for +20
+//
+// Returns:
+//   - SubstatBreakdown: The substats with their rolls
+//   - error: ErrUnknownAffix or ErrInvalidLevel if the list does not fit the level
+func (d *SubstatDecoder) Decode(appendPropIDs []int, level int) (SubstatBreakdown, error) {
+	var breakdown SubstatBreakdown
+	if level < 1 {
+		return breakdown, fmt.Errorf("%w: %d", ErrInvalidLevel, level)
+	}
+
+	breakdown.UpgradeRolls = (level - 1) / 4
+	breakdown.InitialRolls = len(appendPropIDs) - breakdown.UpgradeRolls
+	if breakdown.InitialRolls < 1 {
+		return SubstatBreakdown{}, fmt.Errorf("%w: %d rolls cannot reach level %d", ErrInvalidLevel, len(appendPropIDs), level)
+	}
+
+	index := make(map[mapping.FightProp]int)
+	for i, id := range appendPropIDs {
+		entry, ok := d.affixes[id]
+		if !ok {
+			return SubstatBreakdown{}, fmt.Errorf("%w: substat %d", ErrUnknownAffix, id)
+		}
+
+		roll := SubstatRoll{
+			AffixID: id,
+			Tier:    entry.tier,
+			Value:   entry.affix.PropValue,
+			Initial: i < breakdown.InitialRolls,
+		}
+		prop := entry.affix.PropType
+		pos, ok := index[prop]
+		if !ok {
+			pos = len(breakdown.Substats)
+			index[prop] = pos
+			breakdown.Substats = append(breakdown.Substats, Substat{Prop: prop})
+		}
+		breakdown.Substats[pos].Value += roll.Value
+		breakdown.Substats[pos].Rolls = append(breakdown.Substats[pos].Rolls, roll)
+	}
+	return breakdown, nil
+}
+
+// DecodeEquipment decodes the substats of an artifact from an Enka equipList entry.
+func (d *SubstatDecoder) DecodeEquipment(equip client.Equipment) (SubstatBreakdown, error) {
+	if !equip.IsArtifact() {
+		return SubstatBreakdown{}, fmt.Errorf("%w: item %d is not an artifact", ErrUnknownItem, equip.ItemID)
+	}
+	return d.Decode(equip.Reliquary.AppendPropIDList, equip.Reliquary.Level)
+}
@@ -0,0 +1,175 @@
+package calc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/utkarsh5026/Genka/src/client"
+	"github.com/utkarsh5026/Genka/src/data"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+// WeaponStats are the stats of a weapon at a given level, ascension and refinement.
+type WeaponStats struct {
+	BaseATK float64
+	// SubProp is the secondary stat of the weapon. It is empty for 1 and 2 star weapons.
+	SubProp  mapping.FightProp
+	SubValue float64
+	// Refinement is the refinement rank, from 1 to 5
+	Refinement int
+	// Affix is the refinement's passive, or nil if the weapon has none
+	Affix *data.EquipAffix
+	// PassiveProps are the unconditional stats granted by the passive,
+	// e.g. the ATK% of a weapon that always applies it
+	PassiveProps map[mapping.FightProp]float64
+}
+
+type affixKey struct {
+	id    int
+	level int
+}
+
+// WeaponCalculator computes weapon stats from WeaponExcelConfigData,
+// WeaponCurveExcelConfigData, WeaponPromoteExcelConfigData and the
+// refinements in EquipAffixExcelConfigData.
+type WeaponCalculator struct {
+	weapons  map[int]data.WeaponConfig
+	curves   curveTable
+	promotes map[int][]data.WeaponPromote
+	affixes  map[affixKey]data.EquipAffix
+}
+
+// NewWeaponCalculator indexes the parsed Excel records for lookups.
+func NewWeaponCalculator(weapons []data.WeaponConfig, curves []data.WeaponCurve, promotes []data.WeaponPromote, affixes []data.EquipAffix) *WeaponCalculator {
+	byID := make(map[int]data.WeaponConfig, len(weapons))
+	for _, weapon := range weapons {
+		byID[weapon.ID] = weapon
+	}
+
+	levels := make(map[int][]data.CurveInfo, len(curves))
+	for _, curve := range curves {
+		levels[curve.Level] = curve.CurveInfos
+	}
+
+	byPromoteID := make(map[int][]data.WeaponPromote)
+	for _, promote := range promotes {
+		byPromoteID[promote.WeaponPromoteID] = append(byPromoteID[promote.WeaponPromoteID], promote)
+	}
+	for _, phases := range byPromoteID {
+		sort.Slice(phases, func(i, j int) bool {
+			return phases[i].PromoteLevel < phases[j].PromoteLevel
+		})
+	}
+
+	byAffix := make(map[affixKey]data.EquipAffix, len(affixes))
+	for _, affix := range affixes {
+		byAffix[affixKey{id: affix.ID, level: affix.Level}] = affix
+	}
+
+	return &WeaponCalculator{
+		weapons:  byID,
+		curves:   newCurveTable(levels),
+		promotes: byPromoteID,
+		affixes:  byAffix,
+	}
+}
+
+// LoadWeaponCalculator loads the data files a WeaponCalculator needs,
+// downloading any that are missing.
+func LoadWeaponCalculator(rl *data.ResourceLoader) (*WeaponCalculator, error) {
+	weapons, err := data.LoadExcel(rl, data.WeaponDataFile, data.ParseWeaponConfigs)
+	if err != nil {
+		return nil, err
+	}
+	curves, err := data.LoadExcel(rl, data.WeaponStatCurveFile, data.ParseWeaponCurves)
+	if err != nil {
+		return nil, err
+	}
+	promotes, err := data.LoadExcel(rl, data.WeaponAscensionFile, data.ParseWeaponPromotes)
+	if err != nil {
+		return nil, err
+	}
+	affixes, err := data.LoadExcel(rl, data.ArtifactSetBonusFile, data.ParseEquipAffixes)
+	if err != nil {
+		return nil, err
+	}
+	return NewWeaponCalculator(weapons, curves, promotes, affixes), nil
+}
+
+// Stats returns the stats of a weapon.
+//
+// Parameters:
+//   - weaponID: The ID of the weapon, e.g. 15401
+//   - level: The weapon level (1-90)
+//   - promoteLevel: The ascension phase (0-6); the level must be reachable in it
+//   - refinement: The refinement rank (1-5). Enka's affixMap stores it as rank - 1.
+//
+// Returns:
+//   - WeaponStats: The computed stats
+//   - error: ErrUnknownItem, ErrInvalidLevel or an error about missing curve or affix data
+func (c *WeaponCalculator) Stats(weaponID, level, promoteLevel, refinement int) (WeaponStats, error) {
+	stats := WeaponStats{Refinement: refinement}
+
+	weapon, ok := c.weapons[weaponID]
+	if !ok {
+		return stats, fmt.Errorf("%w: weapon %d", ErrUnknownItem, weaponID)
+	}
+	if refinement < 1 || refinement > 5 {
+		return stats, fmt.Errorf("%w: refinement %d", ErrInvalidLevel, refinement)
+	}
+
+	phases := c.promotes[weapon.WeaponPromoteID]
+	maxLevels := make([]int, len(phases))
+	for i, phase := range phases {
+		maxLevels[i] = phase.UnlockMaxLevel
+	}
+	if err := checkLevel(level, promoteLevel, maxLevels); err != nil {
+		return stats, err
+	}
+
+	bonuses := sumProps(phases[promoteLevel].AddProps)
+	for _, prop := range weapon.WeaponProp {
+		if prop.PropType == "" {
+			continue
+		}
+		value, err := c.curves.apply(prop.Type, level, prop.InitValue)
+		if err != nil {
+			return stats, err
+		}
+		value += bonuses[prop.PropType]
+
+		if prop.PropType == mapping.FIGHT_PROP_BASE_ATTACK {
+			stats.BaseATK = value
+			continue
+		}
+		stats.SubProp = prop.PropType
+		stats.SubValue = value
+	}
+
+	for _, affixID := range weapon.SkillAffix {
+		if affixID == 0 {
+			continue
+		}
+		affix, ok := c.affixes[affixKey{id: affixID, level: refinement - 1}]
+		if !ok {
+			return stats, fmt.Errorf("%w: %d at refinement %d", ErrUnknownAffix, affixID, refinement)
+		}
+		stats.Affix = &affix
+		stats.PassiveProps = sumProps(affix.AddProps)
+		break
+	}
+	return stats, nil
+}
+
+// EquipmentStats returns the stats of a weapon from an Enka equipList entry.
+func (c *WeaponCalculator) EquipmentStats(equip client.Equipment) (WeaponStats, error) {
+	if !equip.IsWeapon() {
+		return WeaponStats{}, fmt.Errorf("%w: item %d is not a weapon", ErrUnknownItem, equip.ItemID)
+	}
+
+	refinement := 1
+	for _, rank := range equip.Weapon.AffixMap {
+		refinement = rank + 1
+	}
+	return c.Stats(equip.ItemID, equip.Weapon.Level, equip.Weapon.PromoteLevel, refinement)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Account is an Enka.Network user account, returned by /api/profile/{username}/.
+type Account struct {
+	ID       int            `json:"id"`
+	Username string         `json:"username"`
+	Profile  AccountProfile `json:"profile"`
+}
+
+// AccountProfile holds the public details of an Enka.Network account.
+type AccountProfile struct {
+	Bio         string `json:"bio"`
+	Level       int    `json:"level"`
+	SignupState int    `json:"signup_state"`
+	ImageUrl    string `json:"image_url"`
+}
+
+// Hoyo is a game account linked to an Enka.Network account.
+// UID is 0 when the owner has hidden it (UIDPublic is false).
+type Hoyo struct {
+	UID        int        `json:"uid"`
+	UIDPublic  bool       `json:"uid_public"`
+	Public     bool       `json:"public"`
+	Verified   bool       `json:"verified"`
+	PlayerInfo PlayerInfo `json:"player_info"`
+	Hash       string     `json:"hash"`
+	Region     string     `json:"region"`
+	Order      int        `json:"order"`
+	HoyoType   int        `json:"hoyo_type"`
+}
+
+// Build is a character build saved on a hoyo.
+// AvatarData has the same layout as the entries of Profile.AvatarInfoList,
+// but Enka does not always fill in the flat block of its equipment.
+type Build struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	AvatarID   string          `json:"avatar_id"`
+	AvatarData AvatarInfo      `json:"avatar_data"`
+	Order      int             `json:"order"`
+	Live       bool            `json:"live"`
+	Settings   json.RawMessage `json:"settings"`
+	Public     bool            `json:"public"`
+	Image      string          `json:"image"`
+	HoyoType   int             `json:"hoyo_type"`
+}
+
+// FetchAccount downloads the Enka.Network account with the given username.
+func (c *EnkaClient) FetchAccount(username string) (*Account, error) {
+	var account Account
+	if err := c.getJSON(fmt.Sprintf("/api/profile/%s/", url.PathEscape(username)), &account); err != nil {
+		return nil, fmt.Errorf("failed to fetch account %s: %w", username, err)
+	}
+	return &account, nil
+}
+
+// FetchHoyos downloads the game accounts linked to an Enka.Network account.
+//
+// Returns:
+//   - map[string]Hoyo: The linked accounts keyed by their hash
+//   - error: nil if successful, otherwise an error describing what went wrong
+func (c *EnkaClient) FetchHoyos(username string) (map[string]Hoyo, error) {
+	var hoyos map[string]Hoyo
+	if err := c.getJSON(fmt.Sprintf("/api/profile/%s/hoyos/", url.PathEscape(username)), &hoyos); err != nil {
+		return nil, fmt.Errorf("failed to fetch hoyos of %s: %w", username, err)
+	}
+	return hoyos, nil
+}
+
+// FetchHoyo downloads a single game account linked to an Enka.Network account.
+func (c *EnkaClient) FetchHoyo(username, hash string) (*Hoyo, error) {
+	var hoyo Hoyo
+	path := fmt.Sprintf("/api/profile/%s/hoyos/%s/", url.PathEscape(username), url.PathEscape(hash))
+	if err := c.getJSON(path, &hoyo); err != nil {
+		return nil, fmt.Errorf("failed to fetch hoyo %s of %s: %w", hash, username, err)
+	}
+	return &hoyo, nil
+}
+
+// FetchBuilds downloads the builds saved on a game account.
+//
+// Parameters:
+//   - username: The Enka.Network username
+//   - hash: The hash of the hoyo, as found in Hoyo.Hash
+//
+// Returns:
+//   - map[int][]Build: The builds keyed by avatar ID
+//   - error: nil if successful, otherwise an error describing what went wrong
+func (c *EnkaClient) FetchBuilds(username, hash string) (map[int][]Build, error) {
+	var builds map[int][]Build
+	path := fmt.Sprintf("/api/profile/%s/hoyos/%s/builds/", url.PathEscape(username), url.PathEscape(hash))
+	if err := c.getJSON(path, &builds); err != nil {
+		return nil, fmt.Errorf("failed to fetch builds of hoyo %s of %s: %w", hash, username, err)
+	}
+	return builds, nil
+}
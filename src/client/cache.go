@@ -0,0 +1,98 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache stores values until their expiry time and coalesces concurrent
+// lookups of the same key into a single fetch.
+type ttlCache[T any] struct {
+	mu       sync.Mutex
+	entries  map[string]cacheEntry[T]
+	inflight map[string]*cacheCall[T]
+	now      func() time.Time
+}
+
+type cacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// cacheCall is a fetch in progress that other callers can wait on
+type cacheCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newTTLCache[T any]() *ttlCache[T] {
+	return &ttlCache[T]{
+		entries:  make(map[string]cacheEntry[T]),
+		inflight: make(map[string]*cacheCall[T]),
+		now:      time.Now,
+	}
+}
+
+// peek returns the cached value for key without fetching it.
+// The second return value is false if there is no unexpired entry.
+func (c *ttlCache[T]) peek(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// get returns the cached value for key if it has not expired yet.
+// Otherwise it calls fetch, which returns the value and the time it expires at.
+// Concurrent callers asking for the same key while a fetch is running
+// wait for that fetch instead of starting their own. Errors are not cached.
+func (c *ttlCache[T]) get(key string, fetch func() (T, time.Time, error)) (T, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		if c.now().Before(entry.expiresAt) {
+			c.mu.Unlock()
+			return entry.value, nil
+		}
+		delete(c.entries, key)
+	}
+
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+
+	call := &cacheCall[T]{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	value, expiresAt, err := fetch()
+	call.value, call.err = value, err
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if err == nil && c.now().Before(expiresAt) {
+		c.removeExpired()
+		c.entries[key] = cacheEntry[T]{value: value, expiresAt: expiresAt}
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	return value, err
+}
+
+// removeExpired drops every expired entry. The caller must hold c.mu.
+func (c *ttlCache[T]) removeExpired() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -1 +1,204 @@
 package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseUrl   = "https://enka.network"
+	DefaultUserAgent = "Genka/1.0 (+https://github.com/utkarsh5026/Genka)"
+	DefaultTimeout   = 30 * time.Second
+)
+
+// EnkaClient talks to the Enka.Network API and decodes its responses into
+// the typed models of this package.
+type EnkaClient struct {
+	baseUrl    string
+	userAgent  string
+	httpClient *http.Client
+	profiles   *ttlCache[*Profile]
+	infos      *ttlCache[*PlayerInfoResponse]
+	retry      *RetryPolicy
+}
+
+// Option configures an EnkaClient.
+type Option func(*EnkaClient)
+
+// WithBaseUrl overrides the API root, e.g. to point the client at an httptest server.
+func WithBaseUrl(baseUrl string) Option {
+	return func(c *EnkaClient) {
+		c.baseUrl = strings.TrimRight(baseUrl, "/")
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+// Enka asks API consumers to identify themselves with a meaningful value.
+func WithUserAgent(userAgent string) Option {
+	return func(c *EnkaClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used to perform requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *EnkaClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCache enables or disables the profile cache.
+// The cache is enabled by default, as Enka asks clients not to request
+// a UID again before the ttl of the previous response has passed.
+func WithCache(enabled bool) Option {
+	return func(c *EnkaClient) {
+		if enabled {
+			c.profiles = newTTLCache[*Profile]()
+			c.infos = newTTLCache[*PlayerInfoResponse]()
+		} else {
+			c.profiles = nil
+			c.infos = nil
+		}
+	}
+}
+
+// NewEnkaClient creates a client for the public Enka.Network API.
+// Without options it targets DefaultBaseUrl with DefaultUserAgent,
+// caches profiles for their ttl and uses an HTTP client that times out
+// after DefaultTimeout.
+func NewEnkaClient(opts ...Option) *EnkaClient {
+	c := &EnkaClient{
+		baseUrl:    DefaultBaseUrl,
+		userAgent:  DefaultUserAgent,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		profiles:   newTTLCache[*Profile](),
+		infos:      newTTLCache[*PlayerInfoResponse](),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// FetchProfile downloads the showcase of the player with the given UID
+// from /api/uid/{uid}.
+//
+// When the cache is enabled, the profile is served from memory until its
+// ExpiresAt time and concurrent calls for the same UID share one request.
+// Cached profiles are shared between callers and must not be modified.
+//
+// Parameters:
+//   - uid: The in-game UID of the player
+//
+// Returns:
+//   - *Profile: The decoded profile
+//   - error: nil if successful, otherwise an error describing what went wrong
+func (c *EnkaClient) FetchProfile(uid string) (*Profile, error) {
+	if c.profiles == nil {
+		profile, _, err := c.fetchProfile(uid)
+		return profile, err
+	}
+	return c.profiles.get(uid, func() (*Profile, time.Time, error) {
+		return c.fetchProfile(uid)
+	})
+}
+
+// fetchProfile requests a profile from the API and stamps it with its expiry time
+func (c *EnkaClient) fetchProfile(uid string) (*Profile, time.Time, error) {
+	var profile Profile
+	if err := c.getJSON(fmt.Sprintf("/api/uid/%s/", url.PathEscape(uid)), &profile); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to fetch profile %s: %w", uid, err)
+	}
+	profile.ExpiresAt = time.Now().Add(time.Duration(profile.TTL) * time.Second)
+	return &profile, profile.ExpiresAt, nil
+}
+
+// FetchPlayerInfo downloads only the account details of the player with the
+// given UID from /api/uid/{uid}?info, skipping the avatarInfoList showcase.
+//
+// It shares the cache with FetchProfile: a cached full profile for the same
+// UID is used instead of sending a request.
+//
+// Parameters:
+//   - uid: The in-game UID of the player
+//
+// Returns:
+//   - *PlayerInfoResponse: The decoded player info
+//   - error: nil if successful, otherwise an error describing what went wrong
+func (c *EnkaClient) FetchPlayerInfo(uid string) (*PlayerInfoResponse, error) {
+	if c.infos == nil {
+		info, _, err := c.fetchPlayerInfo(uid)
+		return info, err
+	}
+
+	if profile, ok := c.profiles.peek(uid); ok {
+		return &PlayerInfoResponse{
+			PlayerInfo: profile.PlayerInfo,
+			TTL:        profile.TTL,
+			UID:        profile.UID,
+			ExpiresAt:  profile.ExpiresAt,
+		}, nil
+	}
+	return c.infos.get(uid, func() (*PlayerInfoResponse, time.Time, error) {
+		return c.fetchPlayerInfo(uid)
+	})
+}
+
+// fetchPlayerInfo requests the player info from the API and stamps it with its expiry time
+func (c *EnkaClient) fetchPlayerInfo(uid string) (*PlayerInfoResponse, time.Time, error) {
+	var info PlayerInfoResponse
+	if err := c.getJSON(fmt.Sprintf("/api/uid/%s/?info", url.PathEscape(uid)), &info); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to fetch player info %s: %w", uid, err)
+	}
+	info.ExpiresAt = time.Now().Add(time.Duration(info.TTL) * time.Second)
+	return &info, info.ExpiresAt, nil
+}
+
+// getJSON performs a GET request against the API and decodes the JSON body into out.
+// Temporary failures are retried when a RetryPolicy is configured.
+func (c *EnkaClient) getJSON(path string, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		err := c.doGetJSON(path, out)
+		if err == nil || c.retry == nil {
+			return err
+		}
+
+		delay, ok := c.retry.delay(attempt, err)
+		if !ok {
+			return err
+		}
+		time.Sleep(delay)
+	}
+}
+
+// doGetJSON performs a single GET request. Non-200 responses are returned as *APIError.
+func (c *EnkaClient) doGetJSON(path string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseUrl+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error performing request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return nil
+}
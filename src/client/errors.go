@@ -0,0 +1,92 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Errors returned by the API, matched by the HTTP status code of the response.
+// They are wrapped in an *APIError, so compare with errors.Is.
+var (
+	ErrInvalidUID         = errors.New("wrong uid format")
+	ErrPlayerNotFound     = errors.New("player does not exist")
+	ErrMaintenance        = errors.New("game maintenance or enka is down")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrServerError        = errors.New("general server error")
+	ErrServiceUnavailable = errors.New("service unavailable")
+	ErrUnexpectedStatus   = errors.New("unexpected status code")
+)
+
+// APIError is returned when the API answers with a non-200 status code.
+type APIError struct {
+	StatusCode int
+	// RetryAfter is the delay requested by the Retry-After header, or 0 if absent
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("enka api returned %d: %v", e.StatusCode, e.Err)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary reports whether the request may succeed if it is sent again later.
+func (e *APIError) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// newAPIError maps the status code of resp to one of the sentinel errors
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		apiErr.Err = ErrInvalidUID
+	case http.StatusNotFound:
+		apiErr.Err = ErrPlayerNotFound
+	case http.StatusFailedDependency:
+		apiErr.Err = ErrMaintenance
+	case http.StatusTooManyRequests:
+		apiErr.Err = ErrRateLimited
+	case http.StatusInternalServerError:
+		apiErr.Err = ErrServerError
+	case http.StatusServiceUnavailable:
+		apiErr.Err = ErrServiceUnavailable
+	default:
+		apiErr.Err = ErrUnexpectedStatus
+	}
+	return apiErr
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+// It returns 0 if the header is empty or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+// Profile is the response of the /api/uid/{uid} endpoint.
+// ExpiresAt is not part of the payload; the client derives it from TTL,
+// the number of seconds Enka asks clients to wait before requesting the UID again.
+type Profile struct {
+	PlayerInfo     PlayerInfo   `json:"playerInfo"`
+	AvatarInfoList []AvatarInfo `json:"avatarInfoList"`
+	TTL            int          `json:"ttl"`
+	UID            string       `json:"uid"`
+	ExpiresAt      time.Time    `json:"-"`
+}
+
+// PlayerInfoResponse is the response of /api/uid/{uid}?info, which only
+// contains the player's account details and no showcase.
+// ExpiresAt is derived from TTL the same way as for Profile.
+type PlayerInfoResponse struct {
+	PlayerInfo PlayerInfo `json:"playerInfo"`
+	TTL        int        `json:"ttl"`
+	UID        string     `json:"uid"`
+	ExpiresAt  time.Time  `json:"-"`
+}
+
+// PlayerInfo holds the public account details shown on a player's profile.
+type PlayerInfo struct {
+	Nickname             string           `json:"nickname"`
+	Level                int              `json:"level"`
+	Signature            string           `json:"signature"`
+	WorldLevel           int              `json:"worldLevel"`
+	NameCardID           int              `json:"nameCardId"`
+	FinishAchievementNum int              `json:"finishAchievementNum"`
+	TowerFloorIndex      int              `json:"towerFloorIndex"`
+	TowerLevelIndex      int              `json:"towerLevelIndex"`
+	TowerStarIndex       int              `json:"towerStarIndex"`
+	ShowAvatarInfoList   []ShowAvatarInfo `json:"showAvatarInfoList"`
+	ShowNameCardIDList   []int            `json:"showNameCardIdList"`
+	ProfilePicture       ProfilePicture   `json:"profilePicture"`
+	TheaterActIndex      int              `json:"theaterActIndex"`
+	TheaterModeIndex     int              `json:"theaterModeIndex"`
+	TheaterStarIndex     int              `json:"theaterStarIndex"`
+	IsShowAvatarTalent   bool             `json:"isShowAvatarTalent"`
+	FetterCount          int              `json:"fetterCount"`
+}
+
+// ShowAvatarInfo is the short summary of a showcased character in PlayerInfo.
+type ShowAvatarInfo struct {
+	AvatarID    int `json:"avatarId"`
+	Level       int `json:"level"`
+	TalentLevel int `json:"talentLevel"`
+	EnergyType  int `json:"energyType"`
+	CostumeID   int `json:"costumeId"`
+}
+
+// ProfilePicture identifies the avatar icon of a player.
+// Older accounts reference it by AvatarID and CostumeID, newer ones by ID.
+type ProfilePicture struct {
+	ID        int `json:"id"`
+	AvatarID  int `json:"avatarId"`
+	CostumeID int `json:"costumeId"`
+}
+
+// AvatarInfo describes a character in the player's showcase.
+//
+// The numeric maps are keyed by strings in the JSON payload; they are decoded
+// into integer keyed maps so they can be indexed with prop and skill IDs directly.
+type AvatarInfo struct {
+	AvatarID                int               `json:"avatarId"`
+	PropMap                 map[int]PropValue `json:"propMap"`
+	FightPropMap            map[int]float64   `json:"fightPropMap"`
+	SkillDepotID            int               `json:"skillDepotId"`
+	InherentProudSkillList  []int             `json:"inherentProudSkillList"`
+	SkillLevelMap           map[int]int       `json:"skillLevelMap"`
+	ProudSkillExtraLevelMap map[int]int       `json:"proudSkillExtraLevelMap"`
+	TalentIDList            []int             `json:"talentIdList"`
+	EquipList               []Equipment       `json:"equipList"`
+	FetterInfo              FetterInfo        `json:"fetterInfo"`
+	CostumeID               int               `json:"costumeId"`
+}
+
+// FightProp returns the value of a stat from FightPropMap by its name,
+// e.g. mapping.FIGHT_PROP_MAX_HP for the final HP of the character.
+// The second return value is false if the stat is not present.
+func (a AvatarInfo) FightProp(fp mapping.FightProp) (float64, bool) {
+	id, ok := fp.ID()
+	if !ok {
+		return 0, false
+	}
+	value, ok := a.FightPropMap[id]
+	return value, ok
+}
+
+// FightProps returns FightPropMap keyed by FightProp.
+// Entries with an unknown numeric ID are left out.
+func (a AvatarInfo) FightProps() map[mapping.FightProp]float64 {
+	props := make(map[mapping.FightProp]float64, len(a.FightPropMap))
+	for id, value := range a.FightPropMap {
+		if fp, ok := mapping.FightPropFromID(id); ok {
+			props[fp] = value
+		}
+	}
+	return props
+}
+
+// Prop returns the value of a propMap entry.
+// Enka omits val when the value is zero and ival is not always filled in,
+// so val is preferred, ival is used as a fallback and an entry with neither is 0.
+// The second return value is false if the entry is missing or malformed.
+func (a AvatarInfo) Prop(pp mapping.PlayerProp) (int, bool) {
+	prop, ok := a.PropMap[int(pp)]
+	if !ok {
+		return 0, false
+	}
+
+	raw := prop.Val
+	if raw == "" {
+		raw = prop.Ival
+	}
+	if raw == "" {
+		return 0, true
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// Level returns the character level, or 0 if it is not present.
+func (a AvatarInfo) Level() int {
+	level, _ := a.Prop(mapping.PROP_LEVEL)
+	return level
+}
+
+// Ascension returns the ascension phase (0-6) of the character.
+func (a AvatarInfo) Ascension() int {
+	ascension, _ := a.Prop(mapping.PROP_BREAK_LEVEL)
+	return ascension
+}
+
+// Experience returns the experience accumulated towards the next level.
+func (a AvatarInfo) Experience() int {
+	exp, _ := a.Prop(mapping.PROP_EXP)
+	return exp
+}
+
+// PropValue is a single entry of AvatarInfo.PropMap.
+// Both values are transmitted as strings and either of them may be missing.
+type PropValue struct {
+	Type int    `json:"type"`
+	Ival string `json:"ival"`
+	Val  string `json:"val"`
+}
+
+// FetterInfo holds the friendship level of a character.
+type FetterInfo struct {
+	ExpLevel int `json:"expLevel"`
+}
+
+// Equipment is an entry of AvatarInfo.EquipList.
+// Exactly one of Reliquary and Weapon is set, depending on the item type.
+type Equipment struct {
+	ItemID    int        `json:"itemId"`
+	Reliquary *Reliquary `json:"reliquary,omitempty"`
+	Weapon    *Weapon    `json:"weapon,omitempty"`
+	Flat      Flat       `json:"flat"`
+}
+
+// IsWeapon reports whether the equipment is a weapon.
+func (e Equipment) IsWeapon() bool {
+	return e.Weapon != nil
+}
+
+// IsArtifact reports whether the equipment is an artifact (reliquary).
+func (e Equipment) IsArtifact() bool {
+	return e.Reliquary != nil
+}
+
+// Reliquary holds the upgrade state of an artifact.
+// Level is one-based, so a +20 artifact has a level of 21.
+type Reliquary struct {
+	Level            int   `json:"level"`
+	Exp              int   `json:"exp"`
+	MainPropID       int   `json:"mainPropId"`
+	AppendPropIDList []int `json:"appendPropIdList"`
+}
+
+// Weapon holds the upgrade state of a weapon.
+// AffixMap maps the weapon's affix ID to its refinement rank, starting at 0 for R1.
+type Weapon struct {
+	Level        int         `json:"level"`
+	PromoteLevel int         `json:"promoteLevel"`
+	AffixMap     map[int]int `json:"affixMap"`
+}
+
+// Flat contains the pre-computed display data of an equipment.
+// Reliquary and weapon specific fields are left empty for the other type.
+type Flat struct {
+	NameTextMapHash    string             `json:"nameTextMapHash"`
+	SetNameTextMapHash string             `json:"setNameTextMapHash,omitempty"`
+	RankLevel          int                `json:"rankLevel"`
+	ItemType           string             `json:"itemType"`
+	Icon               string             `json:"icon"`
+	EquipType          string             `json:"equipType,omitempty"`
+	ReliquaryMainstat  *ReliquaryMainstat `json:"reliquaryMainstat,omitempty"`
+	ReliquarySubstats  []Stat             `json:"reliquarySubstats,omitempty"`
+	WeaponStats        []Stat             `json:"weaponStats,omitempty"`
+}
+
+// ReliquaryMainstat is the main stat of an artifact at its current level.
+type ReliquaryMainstat struct {
+	MainPropID string  `json:"mainPropId"`
+	StatValue  float64 `json:"statValue"`
+}
+
+// Stat is a single weapon stat or artifact substat.
+type Stat struct {
+	AppendPropID string  `json:"appendPropId"`
+	StatValue    float64 `json:"statValue"`
+}
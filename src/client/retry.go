@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+// Only rate limits (429) and server errors (500, 503) are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of attempts made after the first one
+	MaxRetries int
+	// BaseDelay is the delay before the first retry; it doubles on each attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff, MaxBackoff when zero. A
+	// Retry-After header sent by the server takes precedence over the backoff
+	// and is not capped.
+	MaxDelay time.Duration
+}
+
+// MaxBackoff caps the backoff of a RetryPolicy without MaxDelay.
+const MaxBackoff = time.Hour
+
+// DefaultRetryPolicy retries three times, waiting 1s, 2s and 4s.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  time.Second,
+	MaxDelay:   30 * time.Second,
+}
+
+// WithRetry enables retries of temporary failures using the given policy.
+// Requests are not retried unless this option is set.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *EnkaClient) {
+		c.retry = &policy
+	}
+}
+
+// delay returns how long to wait before retry number attempt (starting at 0),
+// or false if err must not be retried.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if attempt >= p.MaxRetries || !errors.As(err, &apiErr) || !apiErr.Temporary() {
+		return 0, false
+	}
+	if apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+
+	return p.Backoff(attempt), true
+}
+
+// Backoff returns the exponential backoff before retry number attempt
+// (starting at 0), capped at MaxDelay, or MaxBackoff when it is not set.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	limit := p.MaxDelay
+	if limit <= 0 {
+		limit = MaxBackoff
+	}
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	attempt = max(attempt, 0)
+	// BaseDelay << attempt would overflow past the limit
+	if attempt >= 63 || p.BaseDelay > limit>>attempt {
+		return limit
+	}
+	return p.BaseDelay << attempt
+}
@@ -0,0 +1,218 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// noneValue is what Enka's store uses for missing elements, icons and constellations
+const noneValue = "None"
+
+// CharacterStore has the layout of Enka's characters.json: the display data
+// of every playable character keyed by avatar ID. The Traveler gets one more
+// entry per element keyed by "{avatarId}-{skillDepotId}".
+type CharacterStore map[string]CharacterStoreEntry
+
+// CharacterStoreEntry is a single character of a CharacterStore.
+type CharacterStoreEntry struct {
+	Element         string                        `json:"Element"`
+	Consts          []string                      `json:"Consts"`
+	SkillOrder      []int                         `json:"SkillOrder"`
+	Skills          map[int]string                `json:"Skills"`
+	ProudMap        map[int]int                   `json:"ProudMap"`
+	NameTextMapHash uint32                        `json:"NameTextMapHash"`
+	SideIconName    string                        `json:"SideIconName"`
+	QualityType     string                        `json:"QualityType"`
+	WeaponType      string                        `json:"WeaponType,omitempty"`
+	Costumes        map[int]CharacterStoreCostume `json:"Costumes,omitempty"`
+}
+
+// CharacterStoreCostume is an alternate outfit of a character.
+type CharacterStoreCostume struct {
+	SideIconName string `json:"sideIconName"`
+	Icon         string `json:"icon"`
+	Art          string `json:"art"`
+	AvatarID     int    `json:"avatarId"`
+}
+
+// CharacterStoreSources are the Excel records a CharacterStore is generated from.
+type CharacterStoreSources struct {
+	Avatars     []AvatarConfig
+	SkillDepots []AvatarSkillDepot
+	Skills      []AvatarSkill
+	Talents     []AvatarTalent
+	Costumes    []AvatarCostume
+}
+
+// LoadCharacterStoreSources loads the data files GenerateCharacterStore needs,
+// downloading any that are missing.
+func LoadCharacterStoreSources(rl *ResourceLoader) (CharacterStoreSources, error) {
+	var src CharacterStoreSources
+	var err error
+
+	if src.Avatars, err = LoadExcel(rl, CharacterDataFile, ParseAvatarConfigs); err != nil {
+		return src, err
+	}
+	if src.SkillDepots, err = LoadExcel(rl, CharacterSkillDepotFile, ParseAvatarSkillDepots); err != nil {
+		return src, err
+	}
+	if src.Skills, err = LoadExcel(rl, CharacterSkillFile, ParseAvatarSkills); err != nil {
+		return src, err
+	}
+	if src.Talents, err = LoadExcel(rl, CharacterConstellationFile, ParseAvatarTalents); err != nil {
+		return src, err
+	}
+	if src.Costumes, err = LoadExcel(rl, CharacterCostumeFile, ParseAvatarCostumes); err != nil {
+		return src, err
+	}
+	return src, nil
+}
+
+// GenerateCharacterStore builds the characters.json store from the Excel data.
+//
+// Only AVATAR_FORMAL avatars are included. The element of a character is the
+// cost element of its elemental burst, the constellations come from
+// AvatarTalentExcelConfigData and the costumes from AvatarCostumeExcelConfigData,
+// leaving out default outfits.
+//
+// The Traveler gets an entry per candidate skill depot and one for the depot
+// it starts with (its skillDepotId), which has no element yet: like in the
+// published store, that entry has Element, constellations and every skill
+// icon but the normal attack's set to None. The entry keyed by the avatar ID
+// alone is the candidate depot sharing the elemental burst of the starting
+// depot, which is Anemo upstream, or else the first candidate.
+//
+// The output matches the published store except for the NameTextMapHash of
+// the element-less Traveler entries: the published store uses 3816664530 for
+// both Travelers there, which nothing in the Excel data points to, while the
+// generator keeps the avatar's own hash.
+//
+// Returns:
+//   - CharacterStore: The generated store
+//   - error: An error if an avatar references a skill depot that does not exist
+func GenerateCharacterStore(src CharacterStoreSources) (CharacterStore, error) {
+	depots := make(map[int]AvatarSkillDepot, len(src.SkillDepots))
+	for _, depot := range src.SkillDepots {
+		depots[depot.ID] = depot
+	}
+	skills := make(map[int]AvatarSkill, len(src.Skills))
+	for _, skill := range src.Skills {
+		skills[skill.ID] = skill
+	}
+	talents := make(map[int]AvatarTalent, len(src.Talents))
+	for _, talent := range src.Talents {
+		talents[talent.TalentID] = talent
+	}
+	costumes := make(map[int]map[int]CharacterStoreCostume)
+	for _, costume := range src.Costumes {
+		if costume.IsDefault {
+			continue
+		}
+		if costumes[costume.CharacterID] == nil {
+			costumes[costume.CharacterID] = make(map[int]CharacterStoreCostume)
+		}
+		name := strings.TrimPrefix(costume.SideIconName, "UI_AvatarIcon_Side_")
+		costumes[costume.CharacterID][costume.SkinID] = CharacterStoreCostume{
+			SideIconName: costume.SideIconName,
+			Icon:         "UI_AvatarIcon_" + name,
+			Art:          "UI_Costume_" + name,
+			AvatarID:     costume.CharacterID,
+		}
+	}
+
+	store := make(CharacterStore)
+	for _, avatar := range src.Avatars {
+		if avatar.UseType != "AVATAR_FORMAL" {
+			continue
+		}
+
+		newEntry := func(depot AvatarSkillDepot, elementless bool) CharacterStoreEntry {
+			entry := storeEntry(avatar, depot, skills, talents, elementless)
+			if len(costumes[avatar.ID]) > 0 {
+				entry.Costumes = costumes[avatar.ID]
+			}
+			return entry
+		}
+
+		if len(avatar.CandSkillDepotIDs) == 0 {
+			depot, ok := depots[avatar.SkillDepotID]
+			if !ok {
+				return nil, fmt.Errorf("avatar %d references unknown skill depot %d", avatar.ID, avatar.SkillDepotID)
+			}
+			store[fmt.Sprint(avatar.ID)] = newEntry(depot, false)
+			continue
+		}
+
+		// The Traveler: the element-less starting depot and one entry per element
+		start, hasStart := depots[avatar.SkillDepotID]
+		if hasStart && !slices.Contains(avatar.CandSkillDepotIDs, start.ID) {
+			store[fmt.Sprintf("%d-%d", avatar.ID, start.ID)] = newEntry(start, true)
+		}
+		defaultID := 0
+		for _, depotID := range avatar.CandSkillDepotIDs {
+			depot, ok := depots[depotID]
+			if !ok {
+				continue
+			}
+			store[fmt.Sprintf("%d-%d", avatar.ID, depotID)] = newEntry(depot, false)
+			if defaultID == 0 || (hasStart && depot.EnergySkill == start.EnergySkill) {
+				defaultID = depotID
+			}
+		}
+		if defaultID != 0 {
+			store[fmt.Sprint(avatar.ID)] = store[fmt.Sprintf("%d-%d", avatar.ID, defaultID)]
+		}
+	}
+	return store, nil
+}
+
+// storeEntry builds the entry of an avatar for one of its skill depots. An
+// elementless depot gets None for its element, constellations and skill
+// icons, except the normal attack's.
+func storeEntry(avatar AvatarConfig, depot AvatarSkillDepot, skills map[int]AvatarSkill, talents map[int]AvatarTalent, elementless bool) CharacterStoreEntry {
+	entry := CharacterStoreEntry{
+		Element:         noneValue,
+		Consts:          make([]string, 0, len(depot.Talents)),
+		Skills:          make(map[int]string),
+		ProudMap:        make(map[int]int),
+		NameTextMapHash: avatar.NameTextMapHash,
+		SideIconName:    avatar.SideIconName,
+		QualityType:     avatar.QualityType,
+	}
+
+	if burst, ok := skills[depot.EnergySkill]; ok && burst.CostElemType != "" && !elementless {
+		entry.Element = burst.CostElemType
+		entry.WeaponType = avatar.WeaponType
+	}
+
+	for _, talentID := range depot.Talents {
+		icon := noneValue
+		if talent, ok := talents[talentID]; ok && talent.Icon != "" && !elementless {
+			icon = talent.Icon
+		}
+		entry.Consts = append(entry.Consts, icon)
+	}
+
+	// normal attack, elemental skill, elemental burst
+	normalAttack := 0
+	if len(depot.Skills) > 0 && depot.Skills[0] != depot.EnergySkill {
+		normalAttack = depot.Skills[0]
+	}
+	order := append(slices.Clone(depot.Skills[:min(len(depot.Skills), 2)]), depot.EnergySkill)
+	for _, skillID := range order {
+		if skillID == 0 {
+			continue
+		}
+		entry.SkillOrder = append(entry.SkillOrder, skillID)
+
+		icon := noneValue
+		skill, ok := skills[skillID]
+		if ok && skill.SkillIcon != "" && (!elementless || skillID == normalAttack) {
+			icon = skill.SkillIcon
+		}
+		entry.Skills[skillID] = icon
+		entry.ProudMap[skillID] = skill.ProudSkillGroupID
+	}
+	return entry
+}
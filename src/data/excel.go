@@ -0,0 +1,51 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+// The structs of the Excel config files only declare the fields Genka uses.
+// Unknown keys, including the obfuscated ones the game data is full of
+// (e.g. OPIHCNNMDBM), are ignored when decoding.
+
+// PropEntry is a stat bonus, as found in addProps lists.
+// Placeholder entries have an empty PropType and a zero Value.
+type PropEntry struct {
+	PropType mapping.FightProp `json:"propType"`
+	Value    float64           `json:"value"`
+}
+
+// ItemCount is an item requirement, as found in costItems lists.
+type ItemCount struct {
+	ID    int `json:"id"`
+	Count int `json:"count"`
+}
+
+// CurveInfo is the multiplier of a grow curve at a single level.
+type CurveInfo struct {
+	Type  string  `json:"type"`
+	Arith string  `json:"arith"`
+	Value float64 `json:"value"`
+}
+
+// parseExcel decodes an Excel config file, which is a JSON array of records
+func parseExcel[T any](file GenshinDataFileName, raw []byte) ([]T, error) {
+	var records []T
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return records, nil
+}
+
+// LoadExcel loads a data file with the ResourceLoader and decodes it with parse,
+// e.g. LoadExcel(rl, CharacterDataFile, ParseAvatarConfigs).
+func LoadExcel[T any](rl *ResourceLoader, file GenshinDataFileName, parse func([]byte) ([]T, error)) ([]T, error) {
+	raw, err := rl.GetFile(file, true)
+	if err != nil {
+		return nil, err
+	}
+	return parse(raw)
+}
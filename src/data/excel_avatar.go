@@ -0,0 +1,247 @@
+package data
+
+import "github.com/utkarsh5026/Genka/src/mapping"
+
+// AvatarConfig is a record of AvatarExcelConfigData.
+type AvatarConfig struct {
+	ID                           int             `json:"id"`
+	NameTextMapHash              uint32          `json:"nameTextMapHash"`
+	DescTextMapHash              uint32          `json:"descTextMapHash"`
+	InfoDescTextMapHash          uint32          `json:"infoDescTextMapHash"`
+	BodyType                     string          `json:"bodyType"`
+	IconName                     string          `json:"iconName"`
+	SideIconName                 string          `json:"sideIconName"`
+	ImageName                    string          `json:"imageName"`
+	QualityType                  string          `json:"qualityType"`
+	WeaponType                   string          `json:"weaponType"`
+	InitialWeapon                int             `json:"initialWeapon"`
+	UseType                      string          `json:"useType"`
+	AvatarIdentityType           string          `json:"avatarIdentityType"`
+	SkillDepotID                 int             `json:"skillDepotId"`
+	CandSkillDepotIDs            []int           `json:"candSkillDepotIds"`
+	AvatarPromoteID              int             `json:"avatarPromoteId"`
+	AvatarPromoteRewardLevelList []int           `json:"avatarPromoteRewardLevelList"`
+	AvatarPromoteRewardIDList    []int           `json:"avatarPromoteRewardIdList"`
+	FeatureTagGroupID            int             `json:"featureTagGroupID"`
+	HpBase                       float64         `json:"hpBase"`
+	AttackBase                   float64         `json:"attackBase"`
+	DefenseBase                  float64         `json:"defenseBase"`
+	Critical                     float64         `json:"critical"`
+	CriticalHurt                 float64         `json:"criticalHurt"`
+	ChargeEfficiency             float64         `json:"chargeEfficiency"`
+	StaminaRecoverSpeed          float64         `json:"staminaRecoverSpeed"`
+	PropGrowCurves               []PropGrowCurve `json:"propGrowCurves"`
+}
+
+// PropGrowCurve names the grow curve a base stat follows when leveling up.
+type PropGrowCurve struct {
+	Type      mapping.FightProp `json:"type"`
+	GrowCurve string            `json:"growCurve"`
+}
+
+// AvatarFetterInfo is a record of FetterInfoExcelConfigData, the character profile.
+type AvatarFetterInfo struct {
+	FetterID                            int    `json:"fetterId"`
+	AvatarID                            int    `json:"avatarId"`
+	InfoBirthMonth                      int    `json:"infoBirthMonth"`
+	InfoBirthDay                        int    `json:"infoBirthDay"`
+	AvatarAssocType                     string `json:"avatarAssocType"`
+	AvatarNativeTextMapHash             uint32 `json:"avatarNativeTextMapHash"`
+	AvatarVisionBeforTextMapHash        uint32 `json:"avatarVisionBeforTextMapHash"`
+	AvatarConstellationBeforTextMapHash uint32 `json:"avatarConstellationBeforTextMapHash"`
+	AvatarTitleTextMapHash              uint32 `json:"avatarTitleTextMapHash"`
+	AvatarDetailTextMapHash             uint32 `json:"avatarDetailTextMapHash"`
+	CvChineseTextMapHash                uint32 `json:"cvChineseTextMapHash"`
+	CvJapaneseTextMapHash               uint32 `json:"cvJapaneseTextMapHash"`
+	CvEnglishTextMapHash                uint32 `json:"cvEnglishTextMapHash"`
+	CvKoreanTextMapHash                 uint32 `json:"cvKoreanTextMapHash"`
+}
+
+// AvatarCostume is a record of AvatarCostumeExcelConfigData.
+type AvatarCostume struct {
+	SkinID          int    `json:"skinId"`
+	CharacterID     int    `json:"characterId"`
+	ItemID          int    `json:"itemId"`
+	NameTextMapHash uint32 `json:"nameTextMapHash"`
+	DescTextMapHash uint32 `json:"descTextMapHash"`
+	JsonName        string `json:"jsonName"`
+	SideIconName    string `json:"sideIconName"`
+	FrontIconName   string `json:"frontIconName"`
+	Quality         int    `json:"quality"`
+	IsDefault       bool   `json:"isDefault"`
+}
+
+// AvatarSkillDepot is a record of AvatarSkillDepotExcelConfigData, the set of
+// skills, passives and constellations of a character (or of an element of the Traveler).
+type AvatarSkillDepot struct {
+	ID                      int                      `json:"id"`
+	EnergySkill             int                      `json:"energySkill"`
+	Skills                  []int                    `json:"skills"`
+	SubSkills               []int                    `json:"subSkills"`
+	Talents                 []int                    `json:"talents"`
+	TalentStarName          string                   `json:"talentStarName"`
+	LeaderTalent            int                      `json:"leaderTalent"`
+	AttackModeSkill         int                      `json:"attackModeSkill"`
+	InherentProudSkillOpens []InherentProudSkillOpen `json:"inherentProudSkillOpens"`
+}
+
+// InherentProudSkillOpen is a passive talent and the ascension that unlocks it.
+type InherentProudSkillOpen struct {
+	ProudSkillGroupID      int `json:"proudSkillGroupId"`
+	NeedAvatarPromoteLevel int `json:"needAvatarPromoteLevel"`
+}
+
+// AvatarSkill is a record of AvatarSkillExcelConfigData, an active skill.
+type AvatarSkill struct {
+	ID                int     `json:"id"`
+	NameTextMapHash   uint32  `json:"nameTextMapHash"`
+	DescTextMapHash   uint32  `json:"descTextMapHash"`
+	SkillIcon         string  `json:"skillIcon"`
+	AbilityName       string  `json:"abilityName"`
+	CdTime            float64 `json:"cdTime"`
+	MaxChargeNum      int     `json:"maxChargeNum"`
+	CostElemType      string  `json:"costElemType"`
+	CostElemVal       float64 `json:"costElemVal"`
+	ProudSkillGroupID int     `json:"proudSkillGroupId"`
+}
+
+// ProudSkill is a record of ProudSkillExcelConfigData, one level of a talent.
+type ProudSkill struct {
+	ProudSkillID          int         `json:"proudSkillId"`
+	ProudSkillGroupID     int         `json:"proudSkillGroupId"`
+	Level                 int         `json:"level"`
+	ProudSkillType        int         `json:"proudSkillType"`
+	NameTextMapHash       uint32      `json:"nameTextMapHash"`
+	DescTextMapHash       uint32      `json:"descTextMapHash"`
+	UnlockDescTextMapHash uint32      `json:"unlockDescTextMapHash"`
+	Icon                  string      `json:"icon"`
+	CoinCost              int         `json:"coinCost"`
+	CostItems             []ItemCount `json:"costItems"`
+	BreakLevel            int         `json:"breakLevel"`
+	ParamDescList         []uint32    `json:"paramDescList"`
+	ParamList             []float64   `json:"paramList"`
+	AddProps              []PropEntry `json:"addProps"`
+}
+
+// AvatarTalent is a record of AvatarTalentExcelConfigData, a constellation.
+type AvatarTalent struct {
+	TalentID          int         `json:"talentId"`
+	NameTextMapHash   uint32      `json:"nameTextMapHash"`
+	DescTextMapHash   uint32      `json:"descTextMapHash"`
+	Icon              string      `json:"icon"`
+	MainCostItemID    int         `json:"mainCostItemId"`
+	MainCostItemCount int         `json:"mainCostItemCount"`
+	OpenConfig        string      `json:"openConfig"`
+	AddProps          []PropEntry `json:"addProps"`
+	ParamList         []float64   `json:"paramList"`
+}
+
+// AvatarPromote is a record of AvatarPromoteExcelConfigData, one ascension phase.
+type AvatarPromote struct {
+	AvatarPromoteID     int         `json:"avatarPromoteId"`
+	PromoteLevel        int         `json:"promoteLevel"`
+	UnlockMaxLevel      int         `json:"unlockMaxLevel"`
+	RequiredPlayerLevel int         `json:"requiredPlayerLevel"`
+	ScoinCost           int         `json:"scoinCost"`
+	CostItems           []ItemCount `json:"costItems"`
+	AddProps            []PropEntry `json:"addProps"`
+}
+
+// AvatarCurve is a record of AvatarCurveExcelConfigData, the grow curve multipliers at a level.
+type AvatarCurve struct {
+	Level      int         `json:"level"`
+	CurveInfos []CurveInfo `json:"curveInfos"`
+}
+
+// AvatarCodex is a record of AvatarCodexExcelConfigData, the release info of a character.
+type AvatarCodex struct {
+	SortID     int    `json:"sortId"`
+	SortFactor int    `json:"sortFactor"`
+	AvatarID   int    `json:"avatarId"`
+	BeginTime  string `json:"beginTime"`
+}
+
+// AvatarHeroEntity is a record of AvatarHeroEntityExcelConfigData, the Traveler entities.
+type AvatarHeroEntity struct {
+	ID       int `json:"id"`
+	AvatarID int `json:"avatarId"`
+}
+
+// TrialAvatarFetter is a record of TrialAvatarFetterDataConfigData.
+type TrialAvatarFetter struct {
+	AvatarID    int `json:"avatarId"`
+	FetterLevel int `json:"fetterLevel"`
+}
+
+// FetterCharacterCard is a record of FetterCharacterCardExcelConfigData,
+// the name card rewarded at max friendship.
+type FetterCharacterCard struct {
+	AvatarID    int `json:"avatarId"`
+	FetterLevel int `json:"fetterLevel"`
+	RewardID    int `json:"rewardId"`
+}
+
+// ParseAvatarConfigs decodes AvatarExcelConfigData.
+func ParseAvatarConfigs(raw []byte) ([]AvatarConfig, error) {
+	return parseExcel[AvatarConfig](CharacterDataFile, raw)
+}
+
+// ParseAvatarFetterInfos decodes FetterInfoExcelConfigData.
+func ParseAvatarFetterInfos(raw []byte) ([]AvatarFetterInfo, error) {
+	return parseExcel[AvatarFetterInfo](CharacterProfileFile, raw)
+}
+
+// ParseAvatarCostumes decodes AvatarCostumeExcelConfigData.
+func ParseAvatarCostumes(raw []byte) ([]AvatarCostume, error) {
+	return parseExcel[AvatarCostume](CharacterCostumeFile, raw)
+}
+
+// ParseAvatarSkillDepots decodes AvatarSkillDepotExcelConfigData.
+func ParseAvatarSkillDepots(raw []byte) ([]AvatarSkillDepot, error) {
+	return parseExcel[AvatarSkillDepot](CharacterSkillDepotFile, raw)
+}
+
+// ParseAvatarSkills decodes AvatarSkillExcelConfigData.
+func ParseAvatarSkills(raw []byte) ([]AvatarSkill, error) {
+	return parseExcel[AvatarSkill](CharacterSkillFile, raw)
+}
+
+// ParseProudSkills decodes ProudSkillExcelConfigData.
+func ParseProudSkills(raw []byte) ([]ProudSkill, error) {
+	return parseExcel[ProudSkill](CharacterTalentFile, raw)
+}
+
+// ParseAvatarTalents decodes AvatarTalentExcelConfigData.
+func ParseAvatarTalents(raw []byte) ([]AvatarTalent, error) {
+	return parseExcel[AvatarTalent](CharacterConstellationFile, raw)
+}
+
+// ParseAvatarPromotes decodes AvatarPromoteExcelConfigData.
+func ParseAvatarPromotes(raw []byte) ([]AvatarPromote, error) {
+	return parseExcel[AvatarPromote](CharacterAscensionFile, raw)
+}
+
+// ParseAvatarCurves decodes AvatarCurveExcelConfigData.
+func ParseAvatarCurves(raw []byte) ([]AvatarCurve, error) {
+	return parseExcel[AvatarCurve](CharacterStatCurveFile, raw)
+}
+
+// ParseAvatarCodexes decodes AvatarCodexExcelConfigData.
+func ParseAvatarCodexes(raw []byte) ([]AvatarCodex, error) {
+	return parseExcel[AvatarCodex](CharacterReleaseInfoFile, raw)
+}
+
+// ParseAvatarHeroEntities decodes AvatarHeroEntityExcelConfigData.
+func ParseAvatarHeroEntities(raw []byte) ([]AvatarHeroEntity, error) {
+	return parseExcel[AvatarHeroEntity](TravelerDataFile, raw)
+}
+
+// ParseTrialAvatarFetters decodes TrialAvatarFetterDataConfigData.
+func ParseTrialAvatarFetters(raw []byte) ([]TrialAvatarFetter, error) {
+	return parseExcel[TrialAvatarFetter](ArchonDataFile, raw)
+}
+
+// ParseFetterCharacterCards decodes FetterCharacterCardExcelConfigData.
+func ParseFetterCharacterCards(raw []byte) ([]FetterCharacterCard, error) {
+	return parseExcel[FetterCharacterCard](FriendshipRewardFile, raw)
+}
@@ -0,0 +1,78 @@
+package data
+
+// ManualTextMap is a record of ManualTextMapConfigData, a UI string referenced by name.
+type ManualTextMap struct {
+	TextMapID                 string `json:"textMapId"`
+	TextMapContentTextMapHash uint32 `json:"textMapContentTextMapHash"`
+}
+
+// MaterialConfig is a record of MaterialExcelConfigData.
+type MaterialConfig struct {
+	ID                    int    `json:"id"`
+	NameTextMapHash       uint32 `json:"nameTextMapHash"`
+	DescTextMapHash       uint32 `json:"descTextMapHash"`
+	TypeDescTextMapHash   uint32 `json:"typeDescTextMapHash"`
+	EffectDescTextMapHash uint32 `json:"effectDescTextMapHash"`
+	Icon                  string `json:"icon"`
+	ItemType              string `json:"itemType"`
+	MaterialType          string `json:"materialType"`
+	RankLevel             int    `json:"rankLevel"`
+	StackLimit            int    `json:"stackLimit"`
+	Rank                  int    `json:"rank"`
+}
+
+// RewardConfig is a record of RewardExcelConfigData.
+type RewardConfig struct {
+	RewardID       int          `json:"rewardId"`
+	RewardItemList []RewardItem `json:"rewardItemList"`
+}
+
+// RewardItem is a single item of a reward. Unused slots are empty.
+type RewardItem struct {
+	ItemID    int `json:"itemId"`
+	ItemCount int `json:"itemCount"`
+}
+
+// ProfilePicture is a record of ProfilePictureExcelConfigData, the icons
+// referenced by the profilePicture of a player.
+type ProfilePicture struct {
+	ID              int    `json:"id"`
+	NameTextMapHash uint32 `json:"nameTextMapHash"`
+	IconPath        string `json:"iconPath"`
+	Type            string `json:"type"`
+	Priority        int    `json:"priority"`
+	UnlockParam     int    `json:"unlockParam"`
+}
+
+// RoleCombatDifficulty is a record of RoleCombatDifficultyExcelConfigData,
+// a difficulty of the Imaginarium Theater.
+type RoleCombatDifficulty struct {
+	DifficultyID    int    `json:"difficultyId"`
+	NameTextMapHash uint32 `json:"nameTextMapHash"`
+	DescTextMapHash uint32 `json:"descTextMapHash"`
+}
+
+// ParseManualTextMaps decodes ManualTextMapConfigData.
+func ParseManualTextMaps(raw []byte) ([]ManualTextMap, error) {
+	return parseExcel[ManualTextMap](TextMapFile, raw)
+}
+
+// ParseMaterialConfigs decodes MaterialExcelConfigData.
+func ParseMaterialConfigs(raw []byte) ([]MaterialConfig, error) {
+	return parseExcel[MaterialConfig](MaterialDataFile, raw)
+}
+
+// ParseRewardConfigs decodes RewardExcelConfigData.
+func ParseRewardConfigs(raw []byte) ([]RewardConfig, error) {
+	return parseExcel[RewardConfig](RewardDataFile, raw)
+}
+
+// ParseProfilePictures decodes ProfilePictureExcelConfigData.
+func ParseProfilePictures(raw []byte) ([]ProfilePicture, error) {
+	return parseExcel[ProfilePicture](ProfilePictureFile, raw)
+}
+
+// ParseRoleCombatDifficulties decodes RoleCombatDifficultyExcelConfigData.
+func ParseRoleCombatDifficulties(raw []byte) ([]RoleCombatDifficulty, error) {
+	return parseExcel[RoleCombatDifficulty](TheaterDifficultyFile, raw)
+}
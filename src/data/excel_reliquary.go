@@ -0,0 +1,107 @@
+package data
+
+import "github.com/utkarsh5026/Genka/src/mapping"
+
+// ReliquaryConfig is a record of ReliquaryExcelConfigData, a single artifact piece.
+type ReliquaryConfig struct {
+	ID                int    `json:"id"`
+	NameTextMapHash   uint32 `json:"nameTextMapHash"`
+	DescTextMapHash   uint32 `json:"descTextMapHash"`
+	Icon              string `json:"icon"`
+	ItemType          string `json:"itemType"`
+	EquipType         string `json:"equipType"`
+	RankLevel         int    `json:"rankLevel"`
+	MaxLevel          int    `json:"maxLevel"`
+	SetID             int    `json:"setId"`
+	MainPropDepotID   int    `json:"mainPropDepotId"`
+	AppendPropDepotID int    `json:"appendPropDepotId"`
+	AppendPropNum     int    `json:"appendPropNum"`
+	AddPropLevels     []int  `json:"addPropLevels"`
+	BaseConvExp       int    `json:"baseConvExp"`
+	StoryID           int    `json:"storyId"`
+}
+
+// ReliquaryLevel is a record of ReliquaryLevelExcelConfigData, the main stat
+// values of an artifact rarity (Rank) at a level. Level is one-based.
+type ReliquaryLevel struct {
+	Rank     int         `json:"rank"`
+	Level    int         `json:"level"`
+	Exp      int         `json:"exp"`
+	AddProps []PropEntry `json:"addProps"`
+}
+
+// ReliquaryMainProp is a record of ReliquaryMainPropExcelConfigData, a possible
+// main stat of an artifact. ID is the mainPropId found in Enka's reliquary data.
+type ReliquaryMainProp struct {
+	ID          int               `json:"id"`
+	PropDepotID int               `json:"propDepotId"`
+	PropType    mapping.FightProp `json:"propType"`
+	AffixName   string            `json:"affixName"`
+	Weight      int               `json:"weight"`
+}
+
+// ReliquaryAffix is a record of ReliquaryAffixExcelConfigData, one roll tier of a substat.
+type ReliquaryAffix struct {
+	ID            int               `json:"id"`
+	DepotID       int               `json:"depotId"`
+	GroupID       int               `json:"groupId"`
+	PropType      mapping.FightProp `json:"propType"`
+	PropValue     float64           `json:"propValue"`
+	Weight        int               `json:"weight"`
+	UpgradeWeight int               `json:"upgradeWeight"`
+}
+
+// ReliquarySet is a record of ReliquarySetExcelConfigData. EquipAffixID
+// references the set bonuses in EquipAffixExcelConfigData, one per SetNeedNum.
+type ReliquarySet struct {
+	SetID        int    `json:"setId"`
+	SetIcon      string `json:"setIcon"`
+	SetNeedNum   []int  `json:"setNeedNum"`
+	EquipAffixID int    `json:"EquipAffixId"`
+	ContainsList []int  `json:"containsList"`
+	BagSortValue int    `json:"bagSortValue"`
+}
+
+// ReliquaryCodex is a record of ReliquaryCodexExcelConfigData, the pieces of
+// a set at a given rarity.
+type ReliquaryCodex struct {
+	ID        int `json:"id"`
+	SuitID    int `json:"suitId"`
+	Level     int `json:"level"`
+	CupID     int `json:"cupId"`
+	LeatherID int `json:"leatherId"`
+	CapID     int `json:"capId"`
+	FlowerID  int `json:"flowerId"`
+	SandID    int `json:"sandId"`
+	SortOrder int `json:"sortOrder"`
+}
+
+// ParseReliquaryConfigs decodes ReliquaryExcelConfigData.
+func ParseReliquaryConfigs(raw []byte) ([]ReliquaryConfig, error) {
+	return parseExcel[ReliquaryConfig](ArtifactDataFile, raw)
+}
+
+// ParseReliquaryLevels decodes ReliquaryLevelExcelConfigData.
+func ParseReliquaryLevels(raw []byte) ([]ReliquaryLevel, error) {
+	return parseExcel[ReliquaryLevel](ArtifactMainStatFile, raw)
+}
+
+// ParseReliquaryMainProps decodes ReliquaryMainPropExcelConfigData.
+func ParseReliquaryMainProps(raw []byte) ([]ReliquaryMainProp, error) {
+	return parseExcel[ReliquaryMainProp](ArtifactMainPropFile, raw)
+}
+
+// ParseReliquaryAffixes decodes ReliquaryAffixExcelConfigData.
+func ParseReliquaryAffixes(raw []byte) ([]ReliquaryAffix, error) {
+	return parseExcel[ReliquaryAffix](ArtifactSubStatFile, raw)
+}
+
+// ParseReliquarySets decodes ReliquarySetExcelConfigData.
+func ParseReliquarySets(raw []byte) ([]ReliquarySet, error) {
+	return parseExcel[ReliquarySet](ArtifactSetDataFile, raw)
+}
+
+// ParseReliquaryCodexes decodes ReliquaryCodexExcelConfigData.
+func ParseReliquaryCodexes(raw []byte) ([]ReliquaryCodex, error) {
+	return parseExcel[ReliquaryCodex](ArtifactRarityDataFile, raw)
+}
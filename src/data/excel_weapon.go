@@ -0,0 +1,91 @@
+package data
+
+import "github.com/utkarsh5026/Genka/src/mapping"
+
+// WeaponConfig is a record of WeaponExcelConfigData.
+type WeaponConfig struct {
+	ID              int          `json:"id"`
+	NameTextMapHash uint32       `json:"nameTextMapHash"`
+	DescTextMapHash uint32       `json:"descTextMapHash"`
+	Icon            string       `json:"icon"`
+	AwakenIcon      string       `json:"awakenIcon"`
+	ItemType        string       `json:"itemType"`
+	WeaponType      string       `json:"weaponType"`
+	RankLevel       int          `json:"rankLevel"`
+	WeaponBaseExp   int          `json:"weaponBaseExp"`
+	WeaponPromoteID int          `json:"weaponPromoteId"`
+	StoryID         int          `json:"storyId"`
+	SkillAffix      []int        `json:"skillAffix"`
+	AwakenCosts     []int        `json:"awakenCosts"`
+	WeaponProp      []WeaponProp `json:"weaponProp"`
+}
+
+// WeaponProp is a stat of a weapon at level 1 and the grow curve it follows.
+type WeaponProp struct {
+	PropType  mapping.FightProp `json:"propType"`
+	InitValue float64           `json:"initValue"`
+	Type      string            `json:"type"`
+}
+
+// WeaponPromote is a record of WeaponPromoteExcelConfigData, one ascension phase.
+type WeaponPromote struct {
+	WeaponPromoteID     int         `json:"weaponPromoteId"`
+	PromoteLevel        int         `json:"promoteLevel"`
+	UnlockMaxLevel      int         `json:"unlockMaxLevel"`
+	RequiredPlayerLevel int         `json:"requiredPlayerLevel"`
+	CoinCost            int         `json:"coinCost"`
+	CostItems           []ItemCount `json:"costItems"`
+	AddProps            []PropEntry `json:"addProps"`
+}
+
+// WeaponCurve is a record of WeaponCurveExcelConfigData, the grow curve multipliers at a level.
+type WeaponCurve struct {
+	Level      int         `json:"level"`
+	CurveInfos []CurveInfo `json:"curveInfos"`
+}
+
+// WeaponCodex is a record of WeaponCodexExcelConfigData, the release info of a weapon.
+type WeaponCodex struct {
+	ID        int `json:"id"`
+	WeaponID  int `json:"weaponId"`
+	SortValue int `json:"sortValue"`
+}
+
+// EquipAffix is a record of EquipAffixExcelConfigData. It holds both weapon
+// refinements and artifact set bonuses; Level is the refinement rank (0 for R1)
+// or the index of the set bonus.
+type EquipAffix struct {
+	AffixID         int         `json:"affixId"`
+	ID              int         `json:"id"`
+	Level           int         `json:"level"`
+	NameTextMapHash uint32      `json:"nameTextMapHash"`
+	DescTextMapHash uint32      `json:"descTextMapHash"`
+	OpenConfig      string      `json:"openConfig"`
+	AddProps        []PropEntry `json:"addProps"`
+	ParamList       []float64   `json:"paramList"`
+}
+
+// ParseWeaponConfigs decodes WeaponExcelConfigData.
+func ParseWeaponConfigs(raw []byte) ([]WeaponConfig, error) {
+	return parseExcel[WeaponConfig](WeaponDataFile, raw)
+}
+
+// ParseWeaponPromotes decodes WeaponPromoteExcelConfigData.
+func ParseWeaponPromotes(raw []byte) ([]WeaponPromote, error) {
+	return parseExcel[WeaponPromote](WeaponAscensionFile, raw)
+}
+
+// ParseWeaponCurves decodes WeaponCurveExcelConfigData.
+func ParseWeaponCurves(raw []byte) ([]WeaponCurve, error) {
+	return parseExcel[WeaponCurve](WeaponStatCurveFile, raw)
+}
+
+// ParseWeaponCodexes decodes WeaponCodexExcelConfigData.
+func ParseWeaponCodexes(raw []byte) ([]WeaponCodex, error) {
+	return parseExcel[WeaponCodex](WeaponReleaseInfoFile, raw)
+}
+
+// ParseEquipAffixes decodes EquipAffixExcelConfigData.
+func ParseEquipAffixes(raw []byte) ([]EquipAffix, error) {
+	return parseExcel[EquipAffix](ArtifactSetBonusFile, raw)
+}
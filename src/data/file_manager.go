@@ -1,100 +1,305 @@
 package data
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sync"
+	"time"
 )
 
-// createDefaultDirectoryPath returns the default directory path for data files
-func createDefaultDirectoryPath() (string, error) {
-	_, filename, _, ok := runtime.Caller(1)
-	if !ok {
-		return "", fmt.Errorf("failed to get current directory")
-	}
-	dirPath := filepath.Join(filepath.Dir(filename), "..", "..", "data")
+// DataDirEnv is the environment variable overriding the default storage root.
+const DataDirEnv = "GENKA_DATA_DIR"
 
-	if err := validatePath(dirPath, true); err != nil {
-		return "", fmt.Errorf("failed to create data directory: %w", err)
+// DefaultDataDir returns the storage root used when none is given: the
+// directory in DataDirEnv when set, os.UserCacheDir()/genka otherwise.
+func DefaultDataDir() (string, error) {
+	if dir := os.Getenv(DataDirEnv); dir != "" {
+		return dir, nil
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user cache directory, set %s: %w", DataDirEnv, err)
 	}
+	return filepath.Join(cacheDir, "genka"), nil
+}
+
+// ErrCorruptFile is returned when a stored file does not match the checksum
+// recorded in the manifest, e.g. after an interrupted write or a manual edit.
+var ErrCorruptFile = errors.New("corrupt data file")
+
+// SaveMode controls what happens to a batch of files when some of them cannot be saved.
+type SaveMode int
 
-	return dirPath, nil
+const (
+	// SaveIndependent saves every file it can and reports the others
+	SaveIndependent SaveMode = iota
+	// SaveAllOrNothing saves no file of a batch unless all of them can be written
+	SaveAllOrNothing
+)
+
+// FileManagerOptions configures NewFileManagerWithOptions.
+type FileManagerOptions struct {
+	// Root is the writable directory files are saved to, DefaultDataDir when empty
+	Root string
+	// ReadOnlyRoots are searched in order after Root when loading files,
+	// e.g. data baked into a container image. They are never written to.
+	ReadOnlyRoots []string
+	// SaveMode sets how batches of files are saved, see SetSaveMode
+	SaveMode SaveMode
+	// Logger receives the events of the FileManager, see SetLogger
+	Logger *slog.Logger
 }
 
+// FileManager stores the data and language files below a root directory,
+// in the data and langs subdirectories, next to the manifest.
 type FileManager struct {
 	directoryPath string
 	langPath      string
 	dataPath      string
+	readOnlyRoots []string
+
+	saveMode   SaveMode
+	manifestMu sync.Mutex
+	logger     *slog.Logger
 }
 
+// NewFileManager creates a FileManager storing files in DefaultDataDir.
 func NewFileManager() (*FileManager, error) {
-	dirPath, err := createDefaultDirectoryPath()
-	if err != nil {
-		return nil, err
+	return NewFileManagerWithOptions(FileManagerOptions{})
+}
+
+// NewFileManagerWithOptions creates a FileManager, creating its writable root
+// if needed.
+//
+// Parameters:
+//   - opts: The roots, save mode and logger of the FileManager
+//
+// Returns:
+//   - *FileManager: The FileManager
+//   - error: An error if no root could be determined or created
+func NewFileManagerWithOptions(opts FileManagerOptions) (*FileManager, error) {
+	dirPath := opts.Root
+	if dirPath == "" {
+		var err error
+		if dirPath, err = DefaultDataDir(); err != nil {
+			return nil, err
+		}
+	}
+	if err := validatePath(dirPath, true); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	fmt.Println("Saving files to", dirPath)
-	return &FileManager{
+	fm := &FileManager{
 		directoryPath: dirPath,
 		langPath:      filepath.Join(dirPath, "langs"),
 		dataPath:      filepath.Join(dirPath, "data"),
-	}, nil
+		readOnlyRoots: append([]string(nil), opts.ReadOnlyRoots...),
+		saveMode:      opts.SaveMode,
+	}
+	fm.SetLogger(opts.Logger)
+	return fm, nil
+}
+
+// Root returns the writable root of the FileManager.
+func (fm *FileManager) Root() string {
+	return fm.directoryPath
+}
+
+// roots returns the writable root followed by the read-only ones
+func (fm *FileManager) roots() []string {
+	return append([]string{fm.directoryPath}, fm.readOnlyRoots...)
+}
+
+// SetLogger sets the logger receiving the save and verification events of
+// the FileManager, which is silent by default.
+func (fm *FileManager) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = discardLogger()
+	}
+	fm.logger = logger.With("component", "FileManager")
+}
+
+// SetSaveMode sets how batches of files are saved, SaveIndependent by default.
+func (fm *FileManager) SetSaveMode(mode SaveMode) {
+	fm.saveMode = mode
 }
 
 // saveFiles saves multiple files to the specified path with the given names and data.
 // It creates the directory if it doesn't exist and saves files concurrently.
 //
+// Every file is first written to a temporary file next to its destination and
+// then renamed over it, so a crash never leaves a truncated file behind. In
+// SaveAllOrNothing mode nothing is renamed unless every file was written.
+// The checksums of the saved files are recorded in the manifest.
+//
 // Parameters:
 //   - path: The directory path where files will be saved
 //   - names: Slice of file names (without .json extension)
 //   - data: Slice of byte slices containing the file contents
 //
 // Returns:
-//   - map[string]string: Map of file names to their full file paths
-//   - error: Any error that occurred during saving
+//   - map[string]string: Map of file names to their full file paths, for the files that were saved
+//   - error: The errors of every file that could not be saved, joined
 func (fm *FileManager) saveFiles(path string, names []string, data [][]byte) (map[string]string, error) {
-	filePaths := make(map[string]string)
 	if err := validatePath(path, true); err != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	var wg sync.WaitGroup
-	var mu sync.Mutex
-	var saveErr error
+	staged := make([]*stagedFile, len(names))
+	errs := make([]error, len(names))
 
 	for i, name := range names {
 		wg.Add(1)
 		go func(i int, name string) {
 			defer wg.Done()
 			filePath := filepath.Join(path, fmt.Sprintf("%s.json", name))
-
-			if !isValidJSON(data[i]) {
-				mu.Lock()
-				saveErr = fmt.Errorf("invalid JSON data for file: %s", name)
-				mu.Unlock()
-				return
-			}
-
-			if err := os.WriteFile(filePath, data[i], 0644); err != nil {
-				mu.Lock()
-				saveErr = fmt.Errorf("failed to save file: %w", err)
-				mu.Unlock()
-				return
-			}
-			mu.Lock()
-			filePaths[name] = filePath
-			mu.Unlock()
+			staged[i], errs[i] = fm.stage(filePath, bytes.NewReader(data[i]), validateJSONFile)
 		}(i, name)
 	}
 	wg.Wait()
 
-	if saveErr != nil {
-		return nil, saveErr
+	if fm.saveMode == SaveAllOrNothing {
+		if err := errors.Join(errs...); err != nil {
+			discard(staged)
+			return nil, fmt.Errorf("no files saved: %w", err)
+		}
+	}
+
+	commitErrs, manifestErr := fm.commit(staged)
+
+	filePaths := make(map[string]string)
+	for i, name := range names {
+		if errs[i] == nil {
+			errs[i] = commitErrs[i]
+		}
+		if errs[i] == nil {
+			filePaths[name] = staged[i].filePath
+		}
+	}
+	return filePaths, errors.Join(append(errs, manifestErr)...)
+}
+
+// stagedFile is a file written to a temporary path, waiting to be renamed over filePath
+type stagedFile struct {
+	tmpPath  string
+	filePath string
+	// entry is recorded in the manifest on commit
+	entry ManifestEntry
+}
+
+// stage streams r to a temporary file next to filePath, hashing it on the way,
+// and checks the result with validate, e.g. validateJSONFile. The returned
+// file has to be committed or discarded.
+func (fm *FileManager) stage(filePath string, r io.Reader, validate func(filePath string) error) (*stagedFile, error) {
+	hash := sha256.New()
+	tmpPath, size, err := writeTempFile(filePath, io.TeeReader(r, hash))
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("invalid data for file: %s: %w", filepath.Base(filePath), err)
+	}
+
+	return &stagedFile{
+		tmpPath:  tmpPath,
+		filePath: filePath,
+		entry: ManifestEntry{
+			File:      fm.manifestKey(filePath),
+			Size:      size,
+			SHA256:    hex.EncodeToString(hash.Sum(nil)),
+			FetchedAt: time.Now().UTC(),
+		},
+	}, nil
+}
+
+// commit renames staged files into place and records them in the manifest.
+// nil entries are skipped.
+//
+// Returns:
+//   - []error: The rename error of each file, nil for the files that were saved or skipped
+//   - error: The error recording the saved files in the manifest
+func (fm *FileManager) commit(staged []*stagedFile) ([]error, error) {
+	errs := make([]error, len(staged))
+	var entries []ManifestEntry
+	for i, file := range staged {
+		if file == nil {
+			continue
+		}
+		if err := os.Rename(file.tmpPath, file.filePath); err != nil {
+			_ = os.Remove(file.tmpPath)
+			errs[i] = fmt.Errorf("failed to save file: %w", err)
+			continue
+		}
+		entries = append(entries, file.entry)
+		fm.logger.Debug("file saved", "file", file.entry.File, "bytes", file.entry.Size)
+	}
+
+	if len(entries) == 0 {
+		return errs, nil
+	}
+	return errs, fm.RecordFiles(entries...)
+}
+
+// discard removes the temporary files of staged files, skipping nil entries
+func discard(staged []*stagedFile) {
+	for _, file := range staged {
+		if file != nil {
+			_ = os.Remove(file.tmpPath)
+		}
+	}
+}
+
+// writeTempFile streams r to a new temporary file next to filePath
+//
+// Returns:
+//   - string: The path of the temporary file
+//   - int64: The number of bytes written
+//   - error: Any error, in which case no temporary file is left behind
+func writeTempFile(filePath string, r io.Reader) (string, int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), fmt.Sprintf(".%s-*.tmp", filepath.Base(filePath)))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	size, err := io.Copy(tmp, r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", 0, fmt.Errorf("failed to write file %s: %w", filepath.Base(filePath), err)
+	}
+	return tmp.Name(), size, nil
+}
+
+// writeFileAtomic replaces the file at filePath with data through a temporary file
+func writeFileAtomic(filePath string, data []byte) error {
+	tmp, _, err := writeTempFile(filePath, bytes.NewReader(data))
+	if err != nil {
+		return err
 	}
-	return filePaths, nil
+	if err := os.Rename(tmp, filePath); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
 }
 
 // SaveLangFiles saves language files to the language directory.
@@ -107,23 +312,20 @@ func (fm *FileManager) saveFiles(path string, names []string, data [][]byte) (ma
 //
 // Returns:
 //   - map[Language]string: Map of Languages to their saved file paths
-//   - error: Any error that occurred during saving
+//   - error: The errors of the files that could not be saved, joined
 func (fm *FileManager) SaveLangFiles(langs []Language, data [][]byte) (map[Language]string, error) {
 	langNames := make([]string, len(langs))
 	for i, lang := range langs {
 		langNames[i] = string(lang)
 	}
 	langFilePaths, err := fm.saveFiles(fm.langPath, langNames, data)
-	if err != nil {
-		return nil, err
-	}
 
 	// Convert map[string]string to map[Language]string
 	result := make(map[Language]string)
 	for i, path := range langFilePaths {
 		result[Language(i)] = path
 	}
-	return result, nil
+	return result, err
 }
 
 // SaveDataFiles saves game data files to the data directory.
@@ -136,23 +338,20 @@ func (fm *FileManager) SaveLangFiles(langs []Language, data [][]byte) (map[Langu
 //
 // Returns:
 //   - map[FileName]string: Map of FileNames to their saved file paths
-//   - error: Any error that occurred during saving
+//   - error: The errors of the files that could not be saved, joined
 func (fm *FileManager) SaveDataFiles(filesNames []GenshinDataFileName, data [][]byte) (map[GenshinDataFileName]string, error) {
 	fileNames := make([]string, len(filesNames))
 	for i, fileName := range filesNames {
 		fileNames[i] = string(fileName)
 	}
 	dataFilePaths, err := fm.saveFiles(fm.dataPath, fileNames, data)
-	if err != nil {
-		return nil, err
-	}
 
 	// Convert map[string]string to map[FileName]string
 	result := make(map[GenshinDataFileName]string)
 	for i, path := range dataFilePaths {
 		result[GenshinDataFileName(i)] = path
 	}
-	return result, nil
+	return result, err
 }
 
 // validatePath checks if a directory path exists and optionally creates it if it doesn't.
@@ -170,20 +369,106 @@ func validatePath(path string, createIfNotExists bool) error {
 	return nil
 }
 
-// IsValidJSON checks if a byte slice contains valid JSON data.
-// It attempts to unmarshal the data into a temporary interface{} to verify syntax.
+// validateJSON checks that r holds exactly one valid JSON value. It reads
+// the document token by token, so large files are never held in memory.
 //
 // Parameters:
-//   - data: Byte slice containing the potential JSON data to validate
+//   - r: The reader containing the potential JSON data to validate
 //
 // Returns:
-//   - bool: True if the data is valid JSON, false otherwise
-func isValidJSON(data []byte) bool {
-	var js interface{}
-	return json.Unmarshal(data, &js) == nil
+//   - error: nil if the data is valid JSON, otherwise the syntax error
+func validateJSON(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	depth := 0
+	values := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			if values == 0 {
+				return errors.New("empty JSON document")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if values > 0 {
+			return errors.New("unexpected data after the JSON value")
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			if delim == '{' || delim == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			values++
+		}
+	}
+}
+
+// validateJSONFile checks that the file at filePath holds valid JSON
+func validateJSONFile(filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return validateJSON(bufio.NewReader(f))
 }
 
+// LoadFile reads a data file saved by SaveDataFiles, from the writable root
+// or else the first read-only root that has it. The contents are checked
+// against the checksum in the manifest of that root and ErrCorruptFile is
+// returned when they do not match.
 func (fm *FileManager) LoadFile(file GenshinDataFileName) ([]byte, error) {
-	filePath := filepath.Join(fm.dataPath, fmt.Sprintf("%s.json", file))
-	return os.ReadFile(filePath)
+	return fm.loadVerified(dataFileKey(file))
+}
+
+// LoadLangFile reads the TextMap of a language saved by SaveLangFiles,
+// looking it up and verifying it like LoadFile.
+func (fm *FileManager) LoadLangFile(lang Language) ([]byte, error) {
+	return fm.loadVerified(langFileKey(lang))
+}
+
+// loadVerified reads the file with the given manifest key from the first
+// root that has it and checks it against the manifest of that root. Files
+// missing from the manifest, e.g. saved by an older version, are not verified.
+func (fm *FileManager) loadVerified(key string) ([]byte, error) {
+	var notFound error
+	for _, root := range fm.roots() {
+		filePath := filepath.Join(root, filepath.FromSlash(key))
+		data, err := os.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			if notFound == nil {
+				notFound = err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return fm.verify(root, filePath, key, data)
+	}
+	return nil, notFound
+}
+
+// verify checks data, read from filePath, against the manifest of root
+func (fm *FileManager) verify(root, filePath, key string, data []byte) ([]byte, error) {
+	manifest, err := fm.manifestOf(root)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := manifest.Files[key]
+	if !ok {
+		return data, nil
+	}
+	sum := sha256.Sum256(data)
+	if int64(len(data)) != entry.Size || hex.EncodeToString(sum[:]) != entry.SHA256 {
+		fm.logger.Warn("checksum mismatch", "file", entry.File, "bytes", len(data), "expected_bytes", entry.Size)
+		return nil, fmt.Errorf("%w: %s does not match the checksum in the manifest", ErrCorruptFile, filePath)
+	}
+	return data, nil
 }
@@ -19,6 +19,7 @@ const (
 	ArtifactSetBonusFile   GenshinDataFileName = "EquipAffixExcelConfigData"
 	ArtifactDataFile       GenshinDataFileName = "ReliquaryExcelConfigData"
 	ArtifactMainStatFile   GenshinDataFileName = "ReliquaryLevelExcelConfigData"
+	ArtifactMainPropFile   GenshinDataFileName = "ReliquaryMainPropExcelConfigData"
 	ArtifactSubStatFile    GenshinDataFileName = "ReliquaryAffixExcelConfigData"
 	ArtifactSetDataFile    GenshinDataFileName = "ReliquarySetExcelConfigData"
 	ArtifactRarityDataFile GenshinDataFileName = "ReliquaryCodexExcelConfigData"
@@ -46,7 +47,7 @@ func (f GenshinDataFileName) IsValid() bool {
 		CharacterConstellationFile, CharacterAscensionFile, CharacterStatCurveFile,
 		CharacterReleaseInfoFile, WeaponDataFile, WeaponAscensionFile,
 		WeaponStatCurveFile, WeaponReleaseInfoFile, ArtifactSetBonusFile,
-		ArtifactDataFile, ArtifactMainStatFile, ArtifactSubStatFile,
+		ArtifactDataFile, ArtifactMainStatFile, ArtifactMainPropFile, ArtifactSubStatFile,
 		ArtifactSetDataFile, ArtifactRarityDataFile, TextMapFile,
 		TravelerDataFile, ArchonDataFile, MaterialDataFile,
 		FriendshipRewardFile, RewardDataFile, ProfilePictureFile,
@@ -80,6 +81,7 @@ func GetGenshinDataFileNames() []GenshinDataFileName {
 		ArtifactSetBonusFile,
 		ArtifactDataFile,
 		ArtifactMainStatFile,
+		ArtifactMainPropFile,
 		ArtifactSubStatFile,
 		ArtifactSetDataFile,
 		ArtifactRarityDataFile,
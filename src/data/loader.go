@@ -1,22 +1,30 @@
 package data
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"net/http"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Language string
 
+// The upstream repository at its master branch.
+//
+// Deprecated: files are downloaded through a DataSource, see DefaultSource and WithSource.
 const (
 	GitlabUrl           = "https://gitlab.com/Dimbreath/AnimeGameData/-/tree/master/"
 	LanguageMapFilesUrl = "https://gitlab.com/Dimbreath/AnimeGameData/-/raw/master/TextMap/"
 	GenshinDataFilesUrl = "https://gitlab.com/Dimbreath/AnimeGameData/-/raw/master/ExcelBinOutput/"
+)
 
+const (
 	LangSimplifiedChinese  Language = "chs"
 	LangTraditionalChinese Language = "cht"
 	LangGerman             Language = "de"
@@ -34,159 +42,629 @@ const (
 
 type ResourceLoader struct {
 	fm             *FileManager
-	loggingEnabled bool
-	logger         *log.Logger
+	logger         *slog.Logger
+	source         DataSource
+	progress       ProgressFunc
+	concurrency    int
+	saveMode       SaveMode
+	requestTimeout time.Duration
+	timeout        time.Duration
+	prune          bool
+	pruneFormat    TextMapFormat
+}
+
+const (
+	// DefaultConcurrency is the number of files a ResourceLoader downloads at once by default.
+	DefaultConcurrency = 4
+	// DefaultRequestTimeout bounds the download of a single file by default.
+	// It is generous as the larger TextMaps weigh hundreds of MB.
+	DefaultRequestTimeout = 10 * time.Minute
+)
+
+// Progress reports how much of a file has been downloaded.
+type Progress struct {
+	// File is the manifest key of the file, e.g. "langs/en.json"
+	File string
+	// Done is the number of bytes downloaded so far
+	Done int64
+	// Total is the size of the file, or -1 if the source did not report it
+	Total int64
+}
+
+// ProgressFunc receives download progress. It is called from the download
+// goroutines, so it must be safe for concurrent use and should return quickly.
+type ProgressFunc func(Progress)
+
+// LoaderOption configures a ResourceLoader.
+type LoaderOption func(*ResourceLoader)
+
+// WithSource sets where files are downloaded from, DefaultSource by default.
+// Use NewFallbackSource to try several sources in order.
+func WithSource(source DataSource) LoaderOption {
+	return func(rl *ResourceLoader) {
+		rl.source = source
+	}
+}
+
+// WithProgress sets a function receiving the progress of every download.
+func WithProgress(fn ProgressFunc) LoaderOption {
+	return func(rl *ResourceLoader) {
+		rl.progress = fn
+	}
+}
+
+// WithConcurrency sets how many files are downloaded at once, DefaultConcurrency by default.
+func WithConcurrency(n int) LoaderOption {
+	return func(rl *ResourceLoader) {
+		if n > 0 {
+			rl.concurrency = n
+		}
+	}
+}
+
+// WithSaveMode sets what happens to the downloaded files of a batch when
+// others fail. Unlike FileManager, a ResourceLoader defaults to
+// SaveAllOrNothing so that the stored files always come from the same
+// download. SaveIndependent keeps every file that was downloaded.
+func WithSaveMode(mode SaveMode) LoaderOption {
+	return func(rl *ResourceLoader) {
+		rl.saveMode = mode
+	}
+}
+
+// WithRequestTimeout bounds the download of each file, DefaultRequestTimeout
+// by default. A zero duration disables it.
+func WithRequestTimeout(d time.Duration) LoaderOption {
+	return func(rl *ResourceLoader) {
+		rl.requestTimeout = d
+	}
+}
+
+// WithTimeout bounds every call downloading files, such as LoadDataFiles or
+// Sync, as a whole. There is no overall deadline by default besides the one
+// of the context passed to the Context variants.
+func WithTimeout(d time.Duration) LoaderOption {
+	return func(rl *ResourceLoader) {
+		rl.timeout = d
+	}
+}
+
+// WithLogger sets the logger receiving the download events of the loader.
+// Records carry the file, url, bytes, duration and language attributes
+// where they apply.
+func WithLogger(logger *slog.Logger) LoaderOption {
+	return func(rl *ResourceLoader) {
+		if logger != nil {
+			rl.logger = logger.With("component", "ResourceLoader")
+		}
+	}
 }
 
-func NewResourceLoader(fm *FileManager, loggingEnabled bool) *ResourceLoader {
-	var logger *log.Logger
+// WithTextMapPruning writes a pruned copy of every downloaded TextMap,
+// holding only the hashes referenced by the stored data files, in the given
+// format. See FileManager.PruneLangFiles and FileManager.LoadPrunedLangFile.
+// Data files should be downloaded before the TextMaps; Sync takes care of it.
+func WithTextMapPruning(format TextMapFormat) LoaderOption {
+	return func(rl *ResourceLoader) {
+		rl.prune = true
+		rl.pruneFormat = format
+	}
+}
+
+// NewResourceLoader creates a loader storing files with fm. The loader is
+// silent unless loggingEnabled is set, which logs as text to stderr, or a
+// logger is given with WithLogger.
+func NewResourceLoader(fm *FileManager, loggingEnabled bool, opts ...LoaderOption) *ResourceLoader {
+	logger := discardLogger()
 	if loggingEnabled {
-		logger = log.New(os.Stdout, "ResourceLoader: ", log.LstdFlags)
+		logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
 	}
-	return &ResourceLoader{
+	rl := &ResourceLoader{
 		fm:             fm,
-		loggingEnabled: loggingEnabled,
-		logger:         logger,
+		logger:         logger.With("component", "ResourceLoader"),
+		source:         DefaultSource(),
+		concurrency:    DefaultConcurrency,
+		saveMode:       SaveAllOrNothing,
+		requestTimeout: DefaultRequestTimeout,
 	}
+	for _, opt := range opts {
+		opt(rl)
+	}
+	return rl
 }
 
-// LoadLangFiles concurrently downloads language files for all configured languages.
-// It uses goroutines to fetch files in parallel, collects any errors that occur,
-// and saves the downloaded files using the FileManager.
-//
-// The function creates an HTTP client and launches a goroutine for each language
-// to download its corresponding file. It waits for all downloads to complete
-// before checking for errors and saving the files.
-//
-// Returns:
-//   - error: Returns nil if all files were successfully downloaded and saved,
-//     or an error describing what went wrong during the process
-func (rl *ResourceLoader) LoadLangFiles(langs []Language) error {
-	var wg sync.WaitGroup
-	result := make([][]byte, len(langs))
-	errs := make([]error, len(langs))
-	client := &http.Client{}
+// remoteFile is a file of the data source and where its local copy is stored
+type remoteFile struct {
+	key      string
+	path     string
+	filePath string
+	// lang is set for TextMaps
+	lang Language
+}
 
-	// Launch goroutines for each language
-	for i := range langs {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
-			lang := langs[idx]
-			url := fmt.Sprintf("%sTextMap%s.json?ref_type=heads&inline=false",
-				LanguageMapFilesUrl,
-				strings.ToUpper(string(lang)),
-			)
-			data, err := rl.loadFileFromUrl(url, client)
-			result[idx] = data
-			errs[idx] = err
-		}(i)
+// ErrBatchAborted is the cause reported for a file that was downloaded but
+// not saved because other files of its batch failed in SaveAllOrNothing mode.
+var ErrBatchAborted = errors.New("not saved because other files failed")
+
+// FileStatus is the outcome of a single file of a download batch.
+type FileStatus int
+
+const (
+	// FileUpdated files were downloaded and saved
+	FileUpdated FileStatus = iota
+	// FileSkipped files were already up to date and not downloaded
+	FileSkipped
+	// FileFailed files were not saved, see FileResult.Err
+	FileFailed
+)
+
+func (s FileStatus) String() string {
+	switch s {
+	case FileUpdated:
+		return "updated"
+	case FileSkipped:
+		return "skipped"
+	case FileFailed:
+		return "failed"
 	}
+	return "unknown"
+}
 
-	wg.Wait()
+// FileResult is the outcome of a file, identified by its manifest key
+// (e.g. "data/AvatarExcelConfigData.json").
+type FileResult struct {
+	File   string
+	Status FileStatus
+	Err    error
+}
 
-	// Check for any errors
-	for i, err := range errs {
-		if err != nil {
-			return fmt.Errorf("failed to load lang file %s: %w", langs[i], err)
+// SyncReport holds the outcome of every file of a download batch.
+type SyncReport struct {
+	Results []FileResult
+}
+
+// Files returns the files with the given status.
+func (r SyncReport) Files(status FileStatus) []string {
+	var files []string
+	for _, result := range r.Results {
+		if result.Status == status {
+			files = append(files, result.File)
 		}
 	}
+	return files
+}
 
-	_, err := rl.fm.SaveLangFiles(langs, result)
-	if err != nil {
-		return fmt.Errorf("failed to save lang files: %w", err)
+// Updated returns the files that were downloaded and saved.
+func (r SyncReport) Updated() []string {
+	return r.Files(FileUpdated)
+}
+
+// Skipped returns the files that were already up to date.
+func (r SyncReport) Skipped() []string {
+	return r.Files(FileSkipped)
+}
+
+// Failed returns the files that were not saved.
+func (r SyncReport) Failed() []string {
+	return r.Files(FileFailed)
+}
+
+// fail marks every file as failed with the same cause
+func (r SyncReport) fail(err error) {
+	for i := range r.Results {
+		r.Results[i].Status, r.Results[i].Err = FileFailed, err
 	}
+}
+
+// LoadLangFiles concurrently downloads language files for all configured languages.
+// It fetches up to the configured concurrency of files in parallel, collects
+// the errors of every file, and saves the downloaded files using the FileManager.
+//
+// Each file is streamed to a temporary file next to its destination. The
+// function waits for all downloads to complete before checking for errors,
+// moving the files into place and recording them in the manifest. Use
+// LoadLangFilesWithReport for the outcome of each file.
+//
+// Returns:
+//   - error: Returns nil if all files were successfully downloaded and saved,
+//     or the errors of the files that failed, joined
+func (rl *ResourceLoader) LoadLangFiles(langs []Language) error {
+	return rl.LoadLangFilesContext(context.Background(), langs)
+}
+
+// LoadLangFilesContext is LoadLangFiles with a context. When ctx is cancelled
+// the downloads in flight are aborted and no file of the batch is saved.
+func (rl *ResourceLoader) LoadLangFilesContext(ctx context.Context, langs []Language) error {
+	_, err := rl.LoadLangFilesWithReportContext(ctx, langs)
+	return err
+}
 
-	return nil
+// LoadLangFilesWithReport is LoadLangFiles also returning the outcome of
+// every file, in the order of langs.
+func (rl *ResourceLoader) LoadLangFilesWithReport(langs []Language) (SyncReport, error) {
+	return rl.LoadLangFilesWithReportContext(context.Background(), langs)
+}
+
+// LoadLangFilesWithReportContext is LoadLangFilesWithReport with a context.
+func (rl *ResourceLoader) LoadLangFilesWithReportContext(ctx context.Context, langs []Language) (SyncReport, error) {
+	report, err := rl.fetchFiles(ctx, rl.langRemotes(langs), false)
+	return report, errors.Join(err, rl.pruneLangs(langsWithStatus(report, langs, FileUpdated)))
 }
 
 // LoadDataFiles concurrently downloads game data files from the configured repository.
-// It uses goroutines to fetch files in parallel, collects any errors that occur,
-// and saves the downloaded files using the FileManager.
+// It fetches up to the configured concurrency of files in parallel, collects
+// the errors of every file, and saves the downloaded files using the FileManager.
 //
 // Parameters:
 //   - dataFiles: A slice of GenshinDataFileName values specifying which files to download
 //
-// The function creates an HTTP client and launches a goroutine for each data file
-// to download its corresponding JSON file. It waits for all downloads to complete
-// before checking for errors and saving the files.
+// Each file is streamed to a temporary file next to its destination. The
+// function waits for all downloads to complete before checking for errors,
+// moving the files into place and recording them in the manifest. Use
+// LoadDataFilesWithReport for the outcome of each file.
 //
 // Returns:
 //   - error: Returns nil if all files were successfully downloaded and saved,
-//     or an error describing what went wrong during the process
+//     or the errors of the files that failed, joined
 func (rl *ResourceLoader) LoadDataFiles(dataFiles []GenshinDataFileName) error {
+	return rl.LoadDataFilesContext(context.Background(), dataFiles)
+}
+
+// LoadDataFilesContext is LoadDataFiles with a context. When ctx is cancelled
+// the downloads in flight are aborted and no file of the batch is saved.
+func (rl *ResourceLoader) LoadDataFilesContext(ctx context.Context, dataFiles []GenshinDataFileName) error {
+	_, err := rl.LoadDataFilesWithReportContext(ctx, dataFiles)
+	return err
+}
+
+// LoadDataFilesWithReport is LoadDataFiles also returning the outcome of
+// every file, in the order of dataFiles.
+func (rl *ResourceLoader) LoadDataFilesWithReport(dataFiles []GenshinDataFileName) (SyncReport, error) {
+	return rl.LoadDataFilesWithReportContext(context.Background(), dataFiles)
+}
+
+// LoadDataFilesWithReportContext is LoadDataFilesWithReport with a context.
+func (rl *ResourceLoader) LoadDataFilesWithReportContext(ctx context.Context, dataFiles []GenshinDataFileName) (SyncReport, error) {
+	return rl.fetchFiles(ctx, rl.dataRemotes(dataFiles), false)
+}
+
+// SyncLangFiles downloads the language files that changed upstream since
+// they were last fetched. Files whose local copy still matches the manifest
+// are requested with If-None-Match and skipped when the server answers
+// 304 Not Modified.
+//
+// Returns:
+//   - SyncReport: The outcome of every file
+//   - error: The errors of the files that failed, joined
+func (rl *ResourceLoader) SyncLangFiles(langs []Language) (SyncReport, error) {
+	return rl.SyncLangFilesContext(context.Background(), langs)
+}
+
+// SyncLangFilesContext is SyncLangFiles with a context.
+func (rl *ResourceLoader) SyncLangFilesContext(ctx context.Context, langs []Language) (SyncReport, error) {
+	report, err := rl.fetchFiles(ctx, rl.langRemotes(langs), true)
+	return report, errors.Join(err, rl.pruneLangs(langsWithStatus(report, langs, FileUpdated)))
+}
+
+// SyncDataFiles downloads the data files that changed upstream since they
+// were last fetched. See SyncLangFiles.
+func (rl *ResourceLoader) SyncDataFiles(dataFiles []GenshinDataFileName) (SyncReport, error) {
+	return rl.SyncDataFilesContext(context.Background(), dataFiles)
+}
+
+// SyncDataFilesContext is SyncDataFiles with a context.
+func (rl *ResourceLoader) SyncDataFilesContext(ctx context.Context, dataFiles []GenshinDataFileName) (SyncReport, error) {
+	return rl.fetchFiles(ctx, rl.dataRemotes(dataFiles), true)
+}
+
+// Sync brings every data file and every language file up to date, downloading
+// only what changed upstream.
+func (rl *ResourceLoader) Sync() (SyncReport, error) {
+	return rl.SyncContext(context.Background())
+}
+
+// SyncContext is Sync with a context.
+func (rl *ResourceLoader) SyncContext(ctx context.Context) (SyncReport, error) {
+	ctx, cancel := rl.withTimeout(ctx)
+	defer cancel()
+
+	langs := AllLanguages()
+	dataReport, dataErr := rl.SyncDataFilesContext(ctx, GetGenshinDataFileNames())
+	langReport, langErr := rl.SyncLangFilesContext(ctx, langs)
+
+	// new data files may reference hashes the pruned TextMaps left out
+	var pruneErr error
+	if len(dataReport.Updated()) > 0 {
+		pruneErr = rl.pruneLangs(langsWithStatus(langReport, langs, FileSkipped))
+	}
+
+	report := SyncReport{Results: append(dataReport.Results, langReport.Results...)}
+	return report, errors.Join(dataErr, langErr, pruneErr)
+}
+
+// pruneLangs writes the pruned TextMaps of langs when pruning is enabled
+func (rl *ResourceLoader) pruneLangs(langs []Language) error {
+	if !rl.prune || len(langs) == 0 {
+		return nil
+	}
+	hashes, err := rl.fm.TextMapHashes()
+	if err != nil {
+		return fmt.Errorf("failed to collect text map hashes: %w", err)
+	}
+	rl.logger.Info("pruning lang files", "languages", len(langs), "hashes", len(hashes))
+	return rl.fm.PruneLangFiles(langs, hashes, rl.pruneFormat)
+}
+
+// langsWithStatus returns the languages of a lang file report with the given status
+func langsWithStatus(report SyncReport, langs []Language, status FileStatus) []Language {
+	var selected []Language
+	for i, result := range report.Results {
+		if result.Status == status {
+			selected = append(selected, langs[i])
+		}
+	}
+	return selected
+}
+
+// withTimeout applies the overall timeout of the loader to ctx
+func (rl *ResourceLoader) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if rl.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, rl.timeout)
+}
+
+// fetchFiles downloads remotes with a pool of workers, moves the files that
+// changed into place and records them in the manifest. When conditional is
+// true, files with an up to date local copy are requested with their ETag.
+//
+// When a file fails, the others are still downloaded. In SaveAllOrNothing
+// mode none of them is saved then; in SaveIndependent mode the ones that
+// succeeded are. When ctx is done, the downloads in flight are aborted and
+// nothing is saved.
+//
+// Parameters:
+//   - ctx: The context of the batch
+//   - remotes: The files to download
+//   - conditional: Whether to send If-None-Match for files recorded in the manifest
+//
+// Returns:
+//   - SyncReport: The outcome of every file
+//   - error: The errors of the files that failed and of the manifest, joined
+func (rl *ResourceLoader) fetchFiles(ctx context.Context, remotes []remoteFile, conditional bool) (SyncReport, error) {
+	ctx, cancel := rl.withTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+
+	report := SyncReport{Results: make([]FileResult, len(remotes))}
+	for i, remote := range remotes {
+		report.Results[i].File = remote.key
+	}
+
+	etags := make([]string, len(remotes))
+	if conditional {
+		keys := make([]string, len(remotes))
+		for i, remote := range remotes {
+			keys[i] = remote.key
+		}
+		var err error
+		if etags, err = rl.fm.cachedETags(keys); err != nil {
+			report.fail(err)
+			return report, err
+		}
+	}
+
 	var wg sync.WaitGroup
-	result := make([][]byte, len(dataFiles))
-	errs := make([]error, len(dataFiles))
-	client := &http.Client{}
+	staged := make([]*stagedFile, len(remotes))
+	errs := make([]error, len(remotes))
+	jobs := make(chan int)
 
-	for i := range dataFiles {
+	for w := 0; w < min(rl.concurrency, len(remotes)); w++ {
 		wg.Add(1)
-		go func(idx int) {
+		go func() {
 			defer wg.Done()
-			file := dataFiles[idx]
-			url := getDataFileUrl(file)
-			data, err := rl.loadFileFromUrl(url, client)
-			result[idx] = data
-			errs[idx] = err
-		}(i)
+			for idx := range jobs {
+				staged[idx], errs[idx] = rl.loadFile(ctx, remotes[idx], etags[idx])
+			}
+		}()
 	}
-
+dispatch:
+	for i := range remotes {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			for j := i; j < len(remotes); j++ {
+				errs[j] = ctx.Err()
+			}
+			break dispatch
+		}
+	}
+	close(jobs)
 	wg.Wait()
 
-	for i, err := range errs {
-		if err != nil {
-			return fmt.Errorf("failed to load data file %s: %w", dataFiles[i], err)
+	if ctx.Err() != nil {
+		// files completed before the cancellation are dropped too
+		for i, file := range staged {
+			if file != nil && errs[i] == nil {
+				errs[i] = ctx.Err()
+			}
 		}
+		discard(staged)
+		staged = make([]*stagedFile, len(remotes))
 	}
 
-	_, err := rl.fm.SaveDataFiles(dataFiles, result)
-	if err != nil {
-		return fmt.Errorf("failed to save data files: %w", err)
+	failed := errors.Join(errs...) != nil
+	if failed && rl.saveMode == SaveAllOrNothing {
+		for i, file := range staged {
+			if file != nil {
+				errs[i] = ErrBatchAborted
+			}
+		}
+		discard(staged)
+		staged = make([]*stagedFile, len(remotes))
+	}
+
+	commitErrs, manifestErr := rl.fm.commit(staged)
+	var joined []error
+	for i := range remotes {
+		result := &report.Results[i]
+		switch {
+		case errs[i] == ErrBatchAborted:
+			result.Status, result.Err = FileFailed, ErrBatchAborted
+		case errs[i] != nil:
+			result.Status, result.Err = FileFailed, fmt.Errorf("failed to load %s: %w", remotes[i].key, errs[i])
+		case commitErrs[i] != nil:
+			result.Status, result.Err = FileFailed, fmt.Errorf("failed to save %s: %w", remotes[i].key, commitErrs[i])
+		case staged[i] == nil:
+			result.Status = FileSkipped
+		default:
+			result.Status = FileUpdated
+		}
+		if result.Err != nil && result.Err != ErrBatchAborted {
+			joined = append(joined, result.Err)
+			rl.logger.LogAttrs(ctx, slog.LevelWarn, "file failed", append(remotes[i].attrs(), slog.Any("error", result.Err))...)
+		}
+	}
+	if manifestErr != nil {
+		joined = append(joined, fmt.Errorf("failed to update manifest: %w", manifestErr))
+	}
+
+	rl.logger.LogAttrs(ctx, slog.LevelInfo, "batch finished",
+		slog.Int("updated", len(report.Updated())),
+		slog.Int("skipped", len(report.Skipped())),
+		slog.Int("failed", len(report.Failed())),
+		slog.Duration("duration", time.Since(start)),
+	)
+	return report, errors.Join(joined...)
+}
+
+// attrs returns the log attributes identifying the file
+func (r remoteFile) attrs() []slog.Attr {
+	attrs := []slog.Attr{slog.String("file", r.key)}
+	if r.lang != "" {
+		attrs = append(attrs, slog.String("language", string(r.lang)))
 	}
+	return attrs
+}
 
-	return nil
+// langRemotes returns the upstream TextMap files of langs
+func (rl *ResourceLoader) langRemotes(langs []Language) []remoteFile {
+	remotes := make([]remoteFile, len(langs))
+	for i, lang := range langs {
+		remotes[i] = remoteFile{
+			key:      langFileKey(lang),
+			path:     fmt.Sprintf("TextMap/TextMap%s.json", strings.ToUpper(string(lang))),
+			filePath: filepath.Join(rl.fm.langPath, fmt.Sprintf("%s.json", lang)),
+			lang:     lang,
+		}
+	}
+	return remotes
 }
 
-// loadFileFromUrl downloads and returns the contents of a file from the given URL.
+// dataRemotes returns the upstream Excel files of dataFiles
+func (rl *ResourceLoader) dataRemotes(dataFiles []GenshinDataFileName) []remoteFile {
+	remotes := make([]remoteFile, len(dataFiles))
+	for i, file := range dataFiles {
+		remotes[i] = remoteFile{
+			key:      dataFileKey(file),
+			path:     dataFilePath(file),
+			filePath: filepath.Join(rl.fm.dataPath, fmt.Sprintf("%s.json", file)),
+		}
+	}
+	return remotes
+}
+
+// loadFile streams a file from the data source to a temporary file next to
+// its destination, reporting progress on the way.
 //
 // Parameters:
-//   - url: The URL to download the file from
-//   - client: The HTTP client to use for the request
+//   - ctx: The context of the batch, bounded by the request timeout for this file
+//   - remote: The file to download
+//   - etag: The ETag of the local copy, sent as If-None-Match when not empty
 //
 // Returns:
-//   - []byte: The contents of the downloaded file
+//   - *stagedFile: The downloaded file, waiting to be committed, or nil when
+//     the source reports that the local copy is up to date
 //   - error: nil if successful, otherwise an error describing what went wrong
-func (rl *ResourceLoader) loadFileFromUrl(url string, client *http.Client) ([]byte, error) {
-	var result []byte
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return result, fmt.Errorf("failed to create request: %w", err)
+func (rl *ResourceLoader) loadFile(ctx context.Context, remote remoteFile, etag string) (*stagedFile, error) {
+	if rl.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rl.requestTimeout)
+		defer cancel()
 	}
 
-	resp, err := client.Do(req)
+	start := time.Now()
+	file, err := rl.source.Open(ctx, remote.path, etag)
 	if err != nil {
-		return result, fmt.Errorf("error downloading data file: %w", err)
+		return nil, err
+	}
+	attrs := append(remote.attrs(), slog.String("url", file.Location))
+	if file.NotModified {
+		rl.logger.LogAttrs(ctx, slog.LevelDebug, "file not modified", attrs...)
+		return nil, nil
 	}
 
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			fmt.Printf("error closing response body: %v\n", err)
+			rl.logger.LogAttrs(ctx, slog.LevelWarn, "error closing response body", append(attrs, slog.Any("error", err))...)
 		}
-	}(resp.Body)
+	}(file.Body)
+
+	if err := validatePath(filepath.Dir(remote.filePath), true); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
 
-	data, err := io.ReadAll(resp.Body)
+	// sources that ignore ctx while reading are interrupted between reads
+	var body io.Reader = &contextReader{ctx: ctx, r: file.Body}
+	if rl.progress != nil {
+		body = &progressReader{r: body, fn: rl.progress, progress: Progress{File: remote.key, Total: file.Size}}
+	}
+	staged, err := rl.fm.stage(remote.filePath, body, validateJSONFile)
 	if err != nil {
-		return result, fmt.Errorf("error reading lang file: %w", err)
+		return nil, fmt.Errorf("error reading %s: %w", remote.path, err)
 	}
-	result = append(result, data...)
-	return result, nil
+	staged.entry.Source = file.Location
+	staged.entry.Ref = file.Ref
+	staged.entry.ETag = file.ETag
+
+	rl.logger.LogAttrs(ctx, slog.LevelInfo, "file downloaded",
+		append(attrs, slog.Int64("bytes", staged.entry.Size), slog.Duration("duration", time.Since(start)))...)
+	return staged, nil
+}
+
+// contextReader fails reads once ctx is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// progressReader reports the bytes read from r to fn
+type progressReader struct {
+	r        io.Reader
+	fn       ProgressFunc
+	progress Progress
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	if n > 0 {
+		pr.progress.Done += int64(n)
+		pr.fn(pr.progress)
+	}
+	return n, err
 }
 
 // GetFile loads a data file from disk or downloads it if missing.
 // It first attempts to load the file from the local filesystem using FileManager.
-// If the file doesn't exist and downloadIfMissing is true, it will download
+// If the file doesn't exist or is corrupt and downloadIfMissing is true, it will download
 // the file from the remote URL and save it locally before returning the contents.
 //
 // Parameters:
@@ -196,26 +674,28 @@ func (rl *ResourceLoader) loadFileFromUrl(url string, client *http.Client) ([]by
 // Returns:
 //   - []byte: The contents of the loaded file
 func (rl *ResourceLoader) GetFile(file GenshinDataFileName, downloadIfMissing bool) ([]byte, error) {
+	return rl.GetFileContext(context.Background(), file, downloadIfMissing)
+}
+
+// GetFileContext is GetFile with a context bounding the download.
+func (rl *ResourceLoader) GetFileContext(ctx context.Context, file GenshinDataFileName, downloadIfMissing bool) ([]byte, error) {
 	data, err := rl.fm.LoadFile(file)
-	if err != nil && os.IsNotExist(err) && downloadIfMissing {
-		url := getDataFileUrl(file)
-		fmt.Printf("File is missing so downloading the data file %s\n from %s\n", file, url)
+	if err != nil && (os.IsNotExist(err) || errors.Is(err, ErrCorruptFile)) && downloadIfMissing {
+		rl.logger.LogAttrs(ctx, slog.LevelInfo, "data file missing, downloading",
+			slog.String("file", dataFileKey(file)), slog.Any("reason", err))
 
-		data, err = rl.loadFileFromUrl(url, http.DefaultClient)
-		if err != nil {
-			return nil, err
-		}
-		_, err = rl.fm.SaveDataFiles([]GenshinDataFileName{file}, [][]byte{data})
-		if err != nil {
+		if err := rl.LoadDataFilesContext(ctx, []GenshinDataFileName{file}); err != nil {
 			return nil, err
 		}
+		data, err = rl.fm.LoadFile(file)
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to load data file %s: %w", file, err)
 	}
 
-	fmt.Printf("Loaded data file %s\n", file)
+	rl.logger.LogAttrs(ctx, slog.LevelDebug, "data file loaded",
+		slog.String("file", dataFileKey(file)), slog.Int("bytes", len(data)))
 	return data, nil
 }
 
@@ -231,17 +711,23 @@ func (rl *ResourceLoader) GetDataDirPath() string {
 
 // DownloadAllDataFiles concurrently downloads all Genshin Impact data files from the remote repository and saves them locally
 //
-// The function spawns a goroutine for each file to download, allowing parallel downloads.
+// The files are downloaded by a pool of workers bounded by the configured
+// concurrency, see LoadDataFiles.
 //
 // Returns:
-//   - error: The first error encountered during downloads, or nil if all downloads succeed
+//   - error: The errors of the files that failed, joined, or nil if all downloads succeed
 func (rl *ResourceLoader) DownloadAllDataFiles() error {
 	fileNames := GetGenshinDataFileNames()
 	return rl.LoadDataFiles(fileNames)
 }
 
 func (rl *ResourceLoader) DownLoadAllLanguageFiles() error {
-	langs := []Language{
+	return rl.LoadLangFiles(AllLanguages())
+}
+
+// AllLanguages returns every language with a TextMap upstream.
+func AllLanguages() []Language {
+	return []Language{
 		LangSimplifiedChinese,
 		LangTraditionalChinese,
 		LangGerman,
@@ -256,11 +742,9 @@ func (rl *ResourceLoader) DownLoadAllLanguageFiles() error {
 		LangThai,
 		LangVietnamese,
 	}
-
-	return rl.LoadLangFiles(langs)
 }
 
-// getDataFileUrl constructs the URL for downloading a Genshin Impact data file
-func getDataFileUrl(file GenshinDataFileName) string {
-	return fmt.Sprintf("%s%s.json?ref_type=heads&inline=false", GenshinDataFilesUrl, file)
+// dataFilePath returns the path of a Genshin Impact data file in the data source
+func dataFilePath(file GenshinDataFileName) string {
+	return fmt.Sprintf("ExcelBinOutput/%s.json", file)
 }
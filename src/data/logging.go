@@ -0,0 +1,20 @@
+package data
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardHandler is a slog.Handler dropping every record, so that the data
+// package stays silent unless a logger is injected
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// discardLogger returns a logger writing nowhere
+func discardLogger() *slog.Logger {
+	return slog.New(discardHandler{})
+}
@@ -0,0 +1,182 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFileName is the name of the manifest FileManager keeps next to
+// the langs and data directories.
+const ManifestFileName = "manifest.json"
+
+// ManifestEntry describes a stored file and where it was downloaded from.
+type ManifestEntry struct {
+	// File is the path of the file relative to the storage root, e.g. "data/AvatarExcelConfigData.json"
+	File   string `json:"file"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	// Source is the URL the file was downloaded from
+	Source string `json:"source"`
+	// Ref is the upstream branch or commit the file was taken from
+	Ref string `json:"ref"`
+	// ETag is the validator returned by the upstream server, used by Sync
+	ETag      string    `json:"etag,omitempty"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// Manifest lists the files stored by a FileManager, keyed by ManifestEntry.File.
+type Manifest struct {
+	Files map[string]ManifestEntry `json:"files"`
+}
+
+// dataFileKey returns the manifest key of a data file
+func dataFileKey(file GenshinDataFileName) string {
+	return path.Join("data", string(file)+".json")
+}
+
+// langFileKey returns the manifest key of a language file
+func langFileKey(lang Language) string {
+	return path.Join("langs", string(lang)+".json")
+}
+
+// manifestKey returns the manifest key of a file stored under the writable root
+func (fm *FileManager) manifestKey(filePath string) string {
+	rel, err := filepath.Rel(fm.directoryPath, filePath)
+	if err != nil {
+		return filepath.ToSlash(filePath)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ManifestPath returns the path of the manifest file of the writable root.
+func (fm *FileManager) ManifestPath() string {
+	return filepath.Join(fm.directoryPath, ManifestFileName)
+}
+
+// LoadManifest reads the manifest of the writable root. An empty manifest is
+// returned when none has been written yet.
+func (fm *FileManager) LoadManifest() (*Manifest, error) {
+	fm.manifestMu.Lock()
+	defer fm.manifestMu.Unlock()
+	return fm.loadManifest()
+}
+
+// RecordFiles adds or replaces entries in the manifest.
+func (fm *FileManager) RecordFiles(entries ...ManifestEntry) error {
+	fm.manifestMu.Lock()
+	defer fm.manifestMu.Unlock()
+
+	manifest, err := fm.loadManifest()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		manifest.Files[entry.File] = entry
+	}
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := validatePath(fm.directoryPath, true); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := writeFileAtomic(fm.ManifestPath(), raw); err != nil {
+		return fmt.Errorf("failed to save manifest: %w", err)
+	}
+	return nil
+}
+
+// loadManifest reads the manifest of the writable root, the caller must hold manifestMu
+func (fm *FileManager) loadManifest() (*Manifest, error) {
+	return readManifest(fm.directoryPath)
+}
+
+// manifestOf reads the manifest of root, locking when it is the writable one
+func (fm *FileManager) manifestOf(root string) (*Manifest, error) {
+	if root == fm.directoryPath {
+		return fm.LoadManifest()
+	}
+	return readManifest(root)
+}
+
+// readManifest reads the manifest stored in root
+func readManifest(root string) (*Manifest, error) {
+	manifest := &Manifest{Files: make(map[string]ManifestEntry)}
+
+	raw, err := os.ReadFile(filepath.Join(root, ManifestFileName))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if err := json.Unmarshal(raw, manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if manifest.Files == nil {
+		manifest.Files = make(map[string]ManifestEntry)
+	}
+	return manifest, nil
+}
+
+// cachedETags returns the ETag recorded for each file in the manifest of the
+// first root holding it, or "" when the file has no ETag or the size or
+// checksum of its local copy no longer matches the manifest, so that a
+// corrupt copy is downloaded again instead of being reported as up to date
+func (fm *FileManager) cachedETags(keys []string) ([]string, error) {
+	roots := fm.roots()
+	manifests := make([]*Manifest, len(roots))
+	for i, root := range roots {
+		manifest, err := fm.manifestOf(root)
+		if err != nil {
+			return nil, err
+		}
+		manifests[i] = manifest
+	}
+
+	etags := make([]string, len(keys))
+	for i, key := range keys {
+		for r, root := range roots {
+			info, err := os.Stat(filepath.Join(root, filepath.FromSlash(key)))
+			if err != nil {
+				continue
+			}
+			entry, ok := manifests[r].Files[key]
+			if !ok || entry.ETag == "" || info.Size() != entry.Size {
+				break
+			}
+			sum, err := fileSHA256(filepath.Join(root, filepath.FromSlash(key)))
+			if err != nil {
+				return nil, err
+			}
+			if sum == entry.SHA256 {
+				etags[i] = entry.ETag
+			}
+			break
+		}
+	}
+	return etags, nil
+}
+
+// fileSHA256 returns the hex encoded SHA-256 of the file at filePath
+func fileSHA256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", filePath, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
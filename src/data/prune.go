@@ -0,0 +1,256 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// textMapHashSuffix ends the name of every Excel field referencing a TextMap entry
+const textMapHashSuffix = "textmaphash"
+
+// prunedLangFileKey returns the manifest key of the pruned TextMap of a language
+func prunedLangFileKey(lang Language, format TextMapFormat) string {
+	ext := ".json"
+	if format == TextMapBinary {
+		ext = ".bin"
+	}
+	return path.Join("langs", "pruned", string(lang)+ext)
+}
+
+// TextMapHashes collects every hash referenced by a *TextMapHash field of the
+// stored data files, such as nameTextMapHash or descTextMapHash. Data files
+// that have not been downloaded are skipped.
+func (fm *FileManager) TextMapHashes() (map[uint32]struct{}, error) {
+	hashes := make(map[uint32]struct{})
+	for _, file := range GetGenshinDataFileNames() {
+		raw, err := fm.LoadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := collectTextMapHashes(bytes.NewReader(raw), hashes); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", file, err)
+		}
+	}
+	return hashes, nil
+}
+
+// collectTextMapHashes walks a JSON document token by token and adds the
+// values of the *TextMapHash fields at any depth to hashes
+func collectTextMapHashes(r io.Reader, hashes map[uint32]struct{}) error {
+	var stack []jsonFrame
+
+	// valueDone marks the value of the innermost object as read
+	valueDone := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if n := len(stack); n > 0 && stack[n-1].expectKey {
+			if key, ok := tok.(string); ok {
+				stack[n-1].key = key
+				stack[n-1].expectKey = false
+				continue
+			}
+			// the closing brace of the object
+			stack = stack[:n-1]
+			valueDone()
+			continue
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			switch v {
+			case '{':
+				stack = append(stack, jsonFrame{object: true, expectKey: true})
+			case '[':
+				stack = append(stack, jsonFrame{})
+			default:
+				stack = stack[:len(stack)-1]
+				valueDone()
+			}
+			continue
+		case json.Number:
+			addTextMapHash(stack, v.String(), hashes)
+		case string:
+			addTextMapHash(stack, v, hashes)
+		}
+		valueDone()
+	}
+}
+
+// jsonFrame is an object or array being walked by collectTextMapHashes
+type jsonFrame struct {
+	object    bool
+	expectKey bool
+	key       string
+}
+
+// addTextMapHash adds a scalar value to hashes when it is the value of a
+// *TextMapHash field of the innermost object of stack
+func addTextMapHash(stack []jsonFrame, value string, hashes map[uint32]struct{}) {
+	if len(stack) == 0 {
+		return
+	}
+	parent := stack[len(stack)-1]
+	if !parent.object || !isTextMapHashField(parent.key) {
+		return
+	}
+	if hash, ok := parseTextMapHash(value); ok {
+		hashes[hash] = struct{}{}
+	}
+}
+
+// PruneLangFiles writes a reduced copy of the TextMap of each language,
+// keeping only the given hashes, e.g. the ones returned by TextMapHashes.
+// The full TextMaps are kept so that Sync can still tell whether they changed.
+//
+// Parameters:
+//   - langs: The languages to prune, whose TextMaps must have been saved
+//   - hashes: The hashes to keep
+//   - format: The format of the pruned files
+//
+// Returns:
+//   - error: The errors of the languages that could not be pruned, joined
+func (fm *FileManager) PruneLangFiles(langs []Language, hashes map[uint32]struct{}, format TextMapFormat) error {
+	keep := func(hash uint32) bool {
+		_, ok := hashes[hash]
+		return ok
+	}
+
+	var errs []error
+	for _, lang := range langs {
+		if err := fm.pruneLangFile(lang, keep, format); err != nil {
+			errs = append(errs, fmt.Errorf("failed to prune lang file %s: %w", lang, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pruneLangFile writes the pruned TextMap of a single language. Languages are
+// pruned one at a time so that only one full TextMap is held in memory.
+func (fm *FileManager) pruneLangFile(lang Language, keep func(uint32) bool, format TextMapFormat) error {
+	raw, err := fm.LoadLangFile(lang)
+	if err != nil {
+		return err
+	}
+	textMap, err := ReadTextMap(bytes.NewReader(raw), keep)
+	if err != nil {
+		return err
+	}
+	table := NewTextTable(textMap)
+
+	var buf bytes.Buffer
+	validate := validateJSONFile
+	if format == TextMapBinary {
+		_, err = table.WriteTo(&buf)
+		validate = validateTextTableFile
+	} else {
+		err = table.WriteJSON(&buf)
+	}
+	if err != nil {
+		return err
+	}
+
+	filePath := filepath.Join(fm.directoryPath, filepath.FromSlash(prunedLangFileKey(lang, format)))
+	if err := validatePath(filepath.Dir(filePath), true); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	staged, err := fm.stage(filePath, &buf, validate)
+	if err != nil {
+		return err
+	}
+	errs, manifestErr := fm.commit([]*stagedFile{staged})
+	if err := errors.Join(errs[0], manifestErr); err != nil {
+		return err
+	}
+
+	// a copy left in the other format would shadow or outlive this one
+	other := TextMapBinary
+	if format == TextMapBinary {
+		other = TextMapJSON
+	}
+	err = os.Remove(filepath.Join(fm.directoryPath, filepath.FromSlash(prunedLangFileKey(lang, other))))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// LoadPrunedLangFile reads the pruned TextMap of a language written by
+// PruneLangFiles, preferring the binary format when both exist.
+func (fm *FileManager) LoadPrunedLangFile(lang Language) (*TextTable, error) {
+	raw, err := fm.loadVerified(prunedLangFileKey(lang, TextMapBinary))
+	if err == nil {
+		return DecodeTextTable(raw)
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	raw, err = fm.loadVerified(prunedLangFileKey(lang, TextMapJSON))
+	if err != nil {
+		return nil, err
+	}
+	textMap, err := ReadTextMap(bytes.NewReader(raw), nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextTable(textMap), nil
+}
+
+// currentPrunedLangFile reads the pruned TextMap of a language like
+// LoadPrunedLangFile, unless the full TextMap was saved after it, in which
+// case a not exist error is returned as if there were no pruned copy
+func (fm *FileManager) currentPrunedLangFile(lang Language) (*TextTable, error) {
+	manifest, err := fm.LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+	// the entry of the format written last belongs to the current pruned copy
+	var pruned time.Time
+	for _, format := range []TextMapFormat{TextMapBinary, TextMapJSON} {
+		if entry, ok := manifest.Files[prunedLangFileKey(lang, format)]; ok && entry.FetchedAt.After(pruned) {
+			pruned = entry.FetchedAt
+		}
+	}
+	if full, ok := manifest.Files[langFileKey(lang)]; ok && !pruned.IsZero() && pruned.Before(full.FetchedAt) {
+		return nil, fmt.Errorf("pruned lang file %s is older than its TextMap: %w", lang, fs.ErrNotExist)
+	}
+	return fm.LoadPrunedLangFile(lang)
+}
+
+// isTextMapHashField reports whether an Excel field references a TextMap entry
+func isTextMapHashField(key string) bool {
+	return strings.HasSuffix(strings.ToLower(key), textMapHashSuffix)
+}
+
+// parseTextMapHash parses a hash found in a data file, as a number or a string
+func parseTextMapHash(value string) (uint32, bool) {
+	hash, err := strconv.ParseUint(value, 10, 32)
+	return uint32(hash), err == nil
+}
@@ -0,0 +1,251 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSourceTemplate is the raw-file URL template of Dimbreath's AnimeGameData repository
+	DefaultSourceTemplate = "https://gitlab.com/Dimbreath/AnimeGameData/-/raw/{ref}/{path}?inline=false"
+	// DefaultSourceRef is the branch used by DefaultSource
+	DefaultSourceRef = "master"
+	// DefaultResponseHeaderTimeout is how long an HTTPSource waits for a server
+	// to start answering. Downloads themselves are bounded by the context.
+	DefaultResponseHeaderTimeout = 30 * time.Second
+)
+
+// ErrSourceFileNotFound is returned by a DataSource that does not have the requested file.
+var ErrSourceFileNotFound = errors.New("file not found in data source")
+
+// SourceFile is a file opened from a DataSource.
+type SourceFile struct {
+	// Body is the contents of the file, nil when NotModified is set. The caller must close it.
+	Body io.ReadCloser
+	// Size is the length of the file in bytes, or -1 if unknown
+	Size int64
+	// ETag identifies this version of the file, empty if the source has no validators
+	ETag string
+	// NotModified is set when the file still matches the ETag passed to Open
+	NotModified bool
+	// Location is where the file was read from, e.g. its URL
+	Location string
+	// Ref is the branch or commit the file was taken from
+	Ref string
+}
+
+// DataSource provides the files of an AnimeGameData style repository, such as
+// "ExcelBinOutput/AvatarExcelConfigData.json" or "TextMap/TextMapEN.json".
+type DataSource interface {
+	// Open returns the file at path, a slash-separated path relative to the
+	// repository root. When etag is not empty and matches the current version
+	// of the file, a SourceFile with NotModified set and no Body is returned.
+	Open(ctx context.Context, path, etag string) (*SourceFile, error)
+}
+
+// HTTPSource reads files over HTTP from a raw-file URL template, which works
+// with GitLab and GitHub mirrors alike. The template placeholders {ref} and
+// {path} are replaced by the git ref and the file path, e.g.
+//
+//	https://raw.githubusercontent.com/owner/AnimeGameData/{ref}/{path}
+type HTTPSource struct {
+	Template string
+	Ref      string
+	Client   *http.Client
+}
+
+// NewHTTPSource creates a source for a raw-file URL template at ref.
+func NewHTTPSource(template, ref string) *HTTPSource {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = DefaultResponseHeaderTimeout
+	return &HTTPSource{Template: template, Ref: ref, Client: &http.Client{Transport: transport}}
+}
+
+// NewPinnedSource creates a source reading the upstream repository at a fixed
+// tag or commit, so that the data matches a specific game version.
+func NewPinnedSource(ref string) *HTTPSource {
+	return NewHTTPSource(DefaultSourceTemplate, ref)
+}
+
+// DefaultSource returns the upstream repository at its master branch.
+func DefaultSource() *HTTPSource {
+	return NewPinnedSource(DefaultSourceRef)
+}
+
+// URL returns the URL of a file.
+func (s *HTTPSource) URL(path string) string {
+	return strings.NewReplacer("{ref}", s.Ref, "{path}", path).Replace(s.Template)
+}
+
+func (s *HTTPSource) Open(ctx context.Context, path, etag string) (*SourceFile, error) {
+	url := s.URL(path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %w", url, err)
+	}
+
+	file := &SourceFile{Size: resp.ContentLength, ETag: resp.Header.Get("ETag"), Location: url, Ref: s.Ref}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		file.Body = resp.Body
+		return file, nil
+	case resp.StatusCode == http.StatusNotModified && etag != "":
+		_ = resp.Body.Close()
+		file.NotModified = true
+		file.ETag = etag
+		return file, nil
+	case resp.StatusCode == http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrSourceFileNotFound, url)
+	default:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("error downloading %s: unexpected status %s", url, resp.Status)
+	}
+}
+
+// DirSource reads files from a local copy of the repository, such as an
+// unpacked archive or a checkout of an internal mirror.
+type DirSource struct {
+	Root string
+	// Ref is reported in the manifest, e.g. the version the copy was taken from
+	Ref string
+}
+
+// NewDirSource creates a source reading files below root.
+func NewDirSource(root string) *DirSource {
+	return &DirSource{Root: root}
+}
+
+func (s *DirSource) Open(_ context.Context, path, etag string) (*SourceFile, error) {
+	filePath := filepath.Join(s.Root, filepath.FromSlash(path))
+	f, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrSourceFileNotFound, filePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	// the modification time and size stand in for an ETag
+	file := &SourceFile{
+		Size:     info.Size(),
+		ETag:     fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+		Location: filePath,
+		Ref:      s.Ref,
+	}
+	if etag != "" && etag == file.ETag {
+		_ = f.Close()
+		file.NotModified = true
+		return file, nil
+	}
+	file.Body = f
+	return file, nil
+}
+
+// GitSource reads files at a pinned ref or commit of a local git clone,
+// without checking it out. It needs the git executable.
+type GitSource struct {
+	Dir string
+	Ref string
+}
+
+// NewGitSource creates a source reading the clone at dir as of ref.
+func NewGitSource(dir, ref string) *GitSource {
+	return &GitSource{Dir: dir, Ref: ref}
+}
+
+func (s *GitSource) Open(ctx context.Context, path, etag string) (*SourceFile, error) {
+	location := fmt.Sprintf("%s:%s", s.Ref, path)
+
+	// the blob hash changes exactly when the file does, which makes it a natural ETag
+	out, err := exec.CommandContext(ctx, "git", "-C", s.Dir, "rev-parse", "--verify", "--quiet", location).Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %s in %s", ErrSourceFileNotFound, location, s.Dir)
+	}
+	blob := strings.TrimSpace(string(out))
+	file := &SourceFile{Size: -1, ETag: fmt.Sprintf(`"%s"`, blob), Location: location, Ref: s.Ref}
+	if etag != "" && etag == file.ETag {
+		file.NotModified = true
+		return file, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", s.Dir, "cat-file", "blob", blob)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run git: %w", err)
+	}
+	file.Body = &commandReader{ReadCloser: stdout, cmd: cmd}
+	return file, nil
+}
+
+// commandReader is the output of a running command, waiting for it on Close
+type commandReader struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (r *commandReader) Close() error {
+	closeErr := r.ReadCloser.Close()
+	if err := r.cmd.Wait(); err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// FallbackSource tries its sources in order and returns the file from the
+// first one that can open it.
+type FallbackSource []DataSource
+
+// NewFallbackSource creates a source trying sources in order.
+func NewFallbackSource(sources ...DataSource) FallbackSource {
+	return FallbackSource(sources)
+}
+
+func (s FallbackSource) Open(ctx context.Context, path, etag string) (*SourceFile, error) {
+	errs := make([]error, 0, len(s))
+	for _, source := range s {
+		file, err := source.Open(ctx, path, etag)
+		if err == nil {
+			return file, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("%w: %s (no sources)", ErrSourceFileNotFound, path)
+	}
+	return nil, errors.Join(errs...)
+}
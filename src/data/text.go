@@ -0,0 +1,93 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+)
+
+// ErrTextNotFound is returned when a hash is missing from every language of a TextResolver.
+var ErrTextNotFound = errors.New("text not found")
+
+// TextResolver resolves TextMap hashes, such as nameTextMapHash, to localized text.
+// Languages are loaded on first use and kept in a TextIndex, which describes
+// the files they are read from.
+type TextResolver struct {
+	index     *TextIndex
+	languages []Language
+}
+
+// NewTextResolver creates a resolver for lang. When a hash is missing from
+// lang, the fallback languages are tried in order, e.g.
+// NewTextResolver(fm, LangJapanese, LangEnglish) resolves jp -> en.
+func NewTextResolver(fm *FileManager, lang Language, fallbacks ...Language) *TextResolver {
+	return NewTextResolverWithIndex(NewTextIndex(fm, 0), lang, fallbacks...)
+}
+
+// NewTextResolverWithIndex creates a resolver for lang reading its TextMaps
+// from index, so that resolvers for different languages can share the loaded
+// TextMaps and a single memory bound.
+func NewTextResolverWithIndex(index *TextIndex, lang Language, fallbacks ...Language) *TextResolver {
+	languages := make([]Language, 0, len(fallbacks)+1)
+	languages = append(languages, lang)
+	languages = append(languages, fallbacks...)
+
+	return &TextResolver{
+		index:     index,
+		languages: languages,
+	}
+}
+
+// Languages returns the primary language followed by its fallbacks.
+func (tr *TextResolver) Languages() []Language {
+	return append([]Language(nil), tr.languages...)
+}
+
+// Resolve returns the text of a hash given in string form, as used by
+// Enka's flat block (e.g. "1240067179").
+//
+// Parameters:
+//   - hash: The decimal TextMap hash
+//
+// Languages whose TextMap has not been downloaded are skipped, so that a
+// chain like jp -> en still resolves when only en is on disk.
+//
+// Returns:
+//   - string: The text in the first language of the chain that has it
+//   - error: ErrTextNotFound if no language has the hash, the not exist error
+//     of the primary language if no TextMap of the chain is on disk, or the
+//     error encountered while reading or parsing a TextMap
+func (tr *TextResolver) Resolve(hash string) (string, error) {
+	h, err := strconv.ParseUint(hash, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid text map hash %q: %w", hash, err)
+	}
+	return tr.ResolveHash(uint32(h))
+}
+
+// ResolveHash returns the text of a hash given in numeric form, as used by
+// the Excel data files (e.g. nameTextMapHash).
+func (tr *TextResolver) ResolveHash(hash uint32) (string, error) {
+	var missing error
+	loaded := false
+	for _, lang := range tr.languages {
+		text, err := tr.index.Lookup(lang, hash)
+		switch {
+		case err == nil:
+			return text, nil
+		case errors.Is(err, ErrTextNotFound):
+			loaded = true
+		case errors.Is(err, fs.ErrNotExist):
+			if missing == nil {
+				missing = err
+			}
+		default:
+			return "", err
+		}
+	}
+	if !loaded && missing != nil {
+		return "", missing
+	}
+	return "", fmt.Errorf("%w: hash %d in %v", ErrTextNotFound, hash, tr.languages)
+}
@@ -0,0 +1,213 @@
+package data
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+)
+
+// TextIndex holds the TextMaps of several languages for hash lookups, keeping
+// memory low when many languages are available but few are in use:
+//
+//   - a language is loaded on its first lookup, from its pruned TextMap
+//     written by FileManager.PruneLangFiles when there is one, or else from
+//     the full TextMap written by FileManager.SaveLangFiles
+//   - hashes are stored as uint32 and repeated texts of a full TextMap share
+//     one string
+//   - beyond maxLanguages, the least recently used language is dropped and
+//     loaded again on its next lookup
+//
+// A pruned TextMap only holds the hashes referenced by the data files, so the
+// first lookup of another hash also loads the full TextMap when it is on disk.
+// A pruned copy older than its full TextMap is ignored.
+//
+// A TextIndex is safe for concurrent use. Loading a language does not block
+// lookups in the languages already loaded.
+type TextIndex struct {
+	fm           *FileManager
+	maxLanguages int
+
+	mu      sync.Mutex
+	entries map[Language]*list.Element
+	// lru holds the *textIndexEntry values, most recently used first
+	lru *list.List
+}
+
+// textIndexEntry is the TextMap of one language, usable once done is closed
+type textIndexEntry struct {
+	lang   Language
+	done   chan struct{}
+	texts  textLookup
+	pruned bool
+	err    error
+
+	// full is the full TextMap behind a pruned one, loaded on its first miss
+	fullOnce sync.Once
+	full     textLookup
+	fullErr  error
+}
+
+// textLookup is a loaded TextMap, either a *TextTable or a textMap
+type textLookup interface {
+	Lookup(hash uint32) (string, bool)
+}
+
+// textMap is a TextMap decoded from its JSON form
+type textMap map[uint32]string
+
+func (m textMap) Lookup(hash uint32) (string, bool) {
+	text, ok := m[hash]
+	return text, ok
+}
+
+// NewTextIndex creates an index keeping at most maxLanguages TextMaps in
+// memory, or all of them when maxLanguages is zero or negative.
+func NewTextIndex(fm *FileManager, maxLanguages int) *TextIndex {
+	return &TextIndex{
+		fm:           fm,
+		maxLanguages: maxLanguages,
+		entries:      make(map[Language]*list.Element),
+		lru:          list.New(),
+	}
+}
+
+// Lookup returns the text of a hash in lang, loading its TextMap if needed.
+//
+// Parameters:
+//   - lang: The language of the text
+//   - hash: The TextMap hash, e.g. a nameTextMapHash
+//
+// Returns:
+//   - string: The text of the hash
+//   - error: ErrTextNotFound if lang has no such hash, or the error
+//     encountered while loading the TextMap
+func (ti *TextIndex) Lookup(lang Language, hash uint32) (string, error) {
+	entry, err := ti.table(lang)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := entry.texts.Lookup(hash); ok {
+		return text, nil
+	}
+
+	if entry.pruned {
+		entry.fullOnce.Do(func() {
+			entry.full, entry.fullErr = ti.loadFull(lang)
+		})
+		if entry.fullErr != nil && !errors.Is(entry.fullErr, fs.ErrNotExist) {
+			return "", entry.fullErr
+		}
+		if entry.full != nil {
+			if text, ok := entry.full.Lookup(hash); ok {
+				return text, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: hash %d in %s", ErrTextNotFound, hash, lang)
+}
+
+// Loaded returns the languages held in memory, most recently used first.
+func (ti *TextIndex) Loaded() []Language {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	langs := make([]Language, 0, ti.lru.Len())
+	for e := ti.lru.Front(); e != nil; e = e.Next() {
+		langs = append(langs, e.Value.(*textIndexEntry).lang)
+	}
+	return langs
+}
+
+// Unload drops the TextMap of lang from memory.
+func (ti *TextIndex) Unload(lang Language) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	if e, ok := ti.entries[lang]; ok {
+		ti.remove(e)
+	}
+}
+
+// table returns the entry of lang, loading its TextMap when it is not in memory
+func (ti *TextIndex) table(lang Language) (*textIndexEntry, error) {
+	ti.mu.Lock()
+	e, ok := ti.entries[lang]
+	if ok {
+		ti.lru.MoveToFront(e)
+	} else {
+		e = ti.lru.PushFront(&textIndexEntry{lang: lang, done: make(chan struct{})})
+		ti.entries[lang] = e
+		for ti.maxLanguages > 0 && ti.lru.Len() > ti.maxLanguages {
+			ti.remove(ti.lru.Back())
+		}
+	}
+	ti.mu.Unlock()
+
+	entry := e.Value.(*textIndexEntry)
+	if !ok {
+		entry.texts, entry.pruned, entry.err = ti.load(lang)
+		close(entry.done)
+		if entry.err != nil {
+			// let the next lookup try again
+			ti.mu.Lock()
+			if ti.entries[lang] == e {
+				ti.remove(e)
+			}
+			ti.mu.Unlock()
+		}
+	}
+
+	<-entry.done
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return entry, nil
+}
+
+// remove drops an entry, the caller must hold mu
+func (ti *TextIndex) remove(e *list.Element) {
+	delete(ti.entries, e.Value.(*textIndexEntry).lang)
+	ti.lru.Remove(e)
+}
+
+// load reads the pruned TextMap of lang if it is current, reporting that it
+// is pruned, or else its full TextMap
+func (ti *TextIndex) load(lang Language) (textLookup, bool, error) {
+	table, err := ti.fm.currentPrunedLangFile(lang)
+	if err == nil {
+		return table, true, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, fmt.Errorf("failed to load pruned text map %s: %w", lang, err)
+	}
+
+	texts, err := ti.loadFull(lang)
+	return texts, false, err
+}
+
+// loadFull reads the full TextMap of lang, interning the texts
+func (ti *TextIndex) loadFull(lang Language) (textLookup, error) {
+	raw, err := ti.fm.LoadLangFile(lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load text map %s: %w", lang, err)
+	}
+
+	// the pool only lives during the load, the texts keep sharing their strings
+	pool := make(map[string]string)
+	intern := func(text string) string {
+		if interned, ok := pool[text]; ok {
+			return interned
+		}
+		pool[text] = text
+		return text
+	}
+
+	texts, err := readTextMap(bytes.NewReader(raw), nil, intern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text map %s: %w", lang, err)
+	}
+	return textMap(texts), nil
+}
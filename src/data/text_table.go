@@ -0,0 +1,204 @@
+package data
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// TextMapFormat is the on-disk format of a pruned TextMap.
+type TextMapFormat int
+
+const (
+	// TextMapJSON stores pruned TextMaps like the upstream ones, {"hash": "text"}
+	TextMapJSON TextMapFormat = iota
+	// TextMapBinary stores pruned TextMaps as a TextTable, which loads without parsing
+	TextMapBinary
+)
+
+// textTableMagic starts every binary TextTable
+var textTableMagic = [4]byte{'G', 'T', 'M', '1'}
+
+// ErrInvalidTextTable is returned when decoding data that is not a binary TextTable.
+var ErrInvalidTextTable = errors.New("invalid text table")
+
+// TextTable is a read-only TextMap: the hashes sorted in ascending order and
+// the texts stored back to back in one string, so a lookup is a binary search.
+//
+// The binary format, all integers being little-endian uint32, is:
+//
+//	"GTM1" | count | hashes[count] | offsets[count+1] | texts
+//
+// where the text of hashes[i] is texts[offsets[i]:offsets[i+1]].
+type TextTable struct {
+	hashes  []uint32
+	offsets []uint32
+	texts   string
+}
+
+// NewTextTable builds a table from a TextMap.
+func NewTextTable(textMap map[uint32]string) *TextTable {
+	hashes := make([]uint32, 0, len(textMap))
+	size := 0
+	for hash, text := range textMap {
+		hashes = append(hashes, hash)
+		size += len(text)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+
+	offsets := make([]uint32, len(hashes)+1)
+	texts := make([]byte, 0, size)
+	for i, hash := range hashes {
+		texts = append(texts, textMap[hash]...)
+		offsets[i+1] = uint32(len(texts))
+	}
+	return &TextTable{hashes: hashes, offsets: offsets, texts: string(texts)}
+}
+
+// Len returns the number of texts in the table.
+func (t *TextTable) Len() int {
+	return len(t.hashes)
+}
+
+// Lookup returns the text of a hash.
+func (t *TextTable) Lookup(hash uint32) (string, bool) {
+	i := sort.Search(len(t.hashes), func(i int) bool { return t.hashes[i] >= hash })
+	if i == len(t.hashes) || t.hashes[i] != hash {
+		return "", false
+	}
+	return t.texts[t.offsets[i]:t.offsets[i+1]], true
+}
+
+// WriteTo writes the table in the binary format.
+func (t *TextTable) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	written := int64(0)
+	write := func(v any) error {
+		if err := binary.Write(bw, binary.LittleEndian, v); err != nil {
+			return err
+		}
+		written += int64(binary.Size(v))
+		return nil
+	}
+
+	for _, v := range []any{textTableMagic, uint32(len(t.hashes)), t.hashes, t.offsets} {
+		if err := write(v); err != nil {
+			return written, err
+		}
+	}
+	n, err := bw.WriteString(t.texts)
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+	return written, bw.Flush()
+}
+
+// WriteJSON writes the table as an upstream style TextMap.
+func (t *TextTable) WriteJSON(w io.Writer) error {
+	textMap := make(map[string]string, len(t.hashes))
+	for i, hash := range t.hashes {
+		textMap[strconv.FormatUint(uint64(hash), 10)] = t.texts[t.offsets[i]:t.offsets[i+1]]
+	}
+	return json.NewEncoder(w).Encode(textMap)
+}
+
+// DecodeTextTable reads a table in the binary format.
+func DecodeTextTable(raw []byte) (*TextTable, error) {
+	if len(raw) < 8 || [4]byte(raw[:4]) != textTableMagic {
+		return nil, fmt.Errorf("%w: bad header", ErrInvalidTextTable)
+	}
+	count := uint64(binary.LittleEndian.Uint32(raw[4:8]))
+	textsStart := 8 + 4*count + 4*(count+1)
+	if uint64(len(raw)) < textsStart {
+		return nil, fmt.Errorf("%w: truncated index", ErrInvalidTextTable)
+	}
+
+	t := &TextTable{
+		hashes:  make([]uint32, count),
+		offsets: make([]uint32, count+1),
+		texts:   string(raw[textsStart:]),
+	}
+	pos := uint64(8)
+	for i := range t.hashes {
+		t.hashes[i] = binary.LittleEndian.Uint32(raw[pos:])
+		pos += 4
+	}
+	for i := range t.offsets {
+		t.offsets[i] = binary.LittleEndian.Uint32(raw[pos:])
+		pos += 4
+	}
+
+	// a corrupt index must not make Lookup panic
+	for i := range t.hashes {
+		if t.offsets[i] > t.offsets[i+1] || (i > 0 && t.hashes[i-1] >= t.hashes[i]) {
+			return nil, fmt.Errorf("%w: unsorted index", ErrInvalidTextTable)
+		}
+	}
+	if t.offsets[0] != 0 || uint64(t.offsets[count]) != uint64(len(t.texts)) {
+		return nil, fmt.Errorf("%w: offsets do not match the texts", ErrInvalidTextTable)
+	}
+	return t, nil
+}
+
+// ReadTextMap decodes an upstream style TextMap token by token, keeping only
+// the hashes for which keep returns true. A nil keep keeps everything.
+// Keys that are not uint32 hashes are skipped.
+func ReadTextMap(r io.Reader, keep func(hash uint32) bool) (map[uint32]string, error) {
+	return readTextMap(r, keep, nil)
+}
+
+// readTextMap is ReadTextMap passing every kept text through intern, if not nil
+func readTextMap(r io.Reader, keep func(hash uint32) bool, intern func(string) string) (map[uint32]string, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+
+	textMap := make(map[uint32]string)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var text string
+		if err := dec.Decode(&text); err != nil {
+			return nil, fmt.Errorf("text of %q: %w", key, err)
+		}
+
+		hash, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			continue
+		}
+		if keep != nil && !keep(uint32(hash)) {
+			continue
+		}
+		if intern != nil {
+			text = intern(text)
+		}
+		textMap[uint32(hash)] = text
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return textMap, nil
+}
+
+// validateTextTableFile checks that the file at filePath holds a binary TextTable
+func validateTextTableFile(filePath string) error {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	_, err = DecodeTextTable(raw)
+	return err
+}
@@ -5,10 +5,12 @@ type FightProp string
 const (
 
 	// Base Stats
-	FIGHT_PROP_BASE_ATTACK FightProp = "FIGHT_PROP_BASE_ATTACK"
-	FIGHT_PROP_HP          FightProp = "FIGHT_PROP_HP"
-	FIGHT_PROP_ATTACK      FightProp = "FIGHT_PROP_ATTACK"
-	FIGHT_PROP_DEFENSE     FightProp = "FIGHT_PROP_DEFENSE"
+	FIGHT_PROP_BASE_HP      FightProp = "FIGHT_PROP_BASE_HP"
+	FIGHT_PROP_BASE_ATTACK  FightProp = "FIGHT_PROP_BASE_ATTACK"
+	FIGHT_PROP_BASE_DEFENSE FightProp = "FIGHT_PROP_BASE_DEFENSE"
+	FIGHT_PROP_HP           FightProp = "FIGHT_PROP_HP"
+	FIGHT_PROP_ATTACK       FightProp = "FIGHT_PROP_ATTACK"
+	FIGHT_PROP_DEFENSE      FightProp = "FIGHT_PROP_DEFENSE"
 
 	// Percent Stats
 	FIGHT_PROP_HP_PERCENT      FightProp = "FIGHT_PROP_HP_PERCENT"
@@ -17,11 +19,13 @@ const (
 
 	// CRIT stats
 	FIGHT_PROP_CRITICAL      FightProp = "FIGHT_PROP_CRITICAL"
+	FIGHT_PROP_ANTI_CRITICAL FightProp = "FIGHT_PROP_ANTI_CRITICAL"
 	FIGHT_PROP_CRITICAL_HURT FightProp = "FIGHT_PROP_CRITICAL_HURT"
 
 	// Other Stats
 	FIGHT_PROP_CHARGE_EFFICIENCY FightProp = "FIGHT_PROP_CHARGE_EFFICIENCY"
 	FIGHT_PROP_HEAL_ADD          FightProp = "FIGHT_PROP_HEAL_ADD"
+	FIGHT_PROP_HEALED_ADD        FightProp = "FIGHT_PROP_HEALED_ADD"
 	FIGHT_PROP_ELEMENT_MASTERY   FightProp = "FIGHT_PROP_ELEMENT_MASTERY"
 
 	// Damage Bonus Stats
@@ -33,31 +37,189 @@ const (
 	FIGHT_PROP_ICE_ADD_HURT      FightProp = "FIGHT_PROP_ICE_ADD_HURT"
 	FIGHT_PROP_ROCK_ADD_HURT     FightProp = "FIGHT_PROP_ROCK_ADD_HURT"
 	FIGHT_PROP_GRASS_ADD_HURT    FightProp = "FIGHT_PROP_GRASS_ADD_HURT"
+
+	// Resistance Stats
+	FIGHT_PROP_PHYSICAL_SUB_HURT FightProp = "FIGHT_PROP_PHYSICAL_SUB_HURT"
+	FIGHT_PROP_FIRE_SUB_HURT     FightProp = "FIGHT_PROP_FIRE_SUB_HURT"
+	FIGHT_PROP_ELEC_SUB_HURT     FightProp = "FIGHT_PROP_ELEC_SUB_HURT"
+	FIGHT_PROP_WATER_SUB_HURT    FightProp = "FIGHT_PROP_WATER_SUB_HURT"
+	FIGHT_PROP_GRASS_SUB_HURT    FightProp = "FIGHT_PROP_GRASS_SUB_HURT"
+	FIGHT_PROP_WIND_SUB_HURT     FightProp = "FIGHT_PROP_WIND_SUB_HURT"
+	FIGHT_PROP_ROCK_SUB_HURT     FightProp = "FIGHT_PROP_ROCK_SUB_HURT"
+	FIGHT_PROP_ICE_SUB_HURT      FightProp = "FIGHT_PROP_ICE_SUB_HURT"
+
+	// Energy Cost Stats
+	FIGHT_PROP_FIRE_ENERGY_COST  FightProp = "FIGHT_PROP_FIRE_ENERGY_COST"
+	FIGHT_PROP_ELEC_ENERGY_COST  FightProp = "FIGHT_PROP_ELEC_ENERGY_COST"
+	FIGHT_PROP_WATER_ENERGY_COST FightProp = "FIGHT_PROP_WATER_ENERGY_COST"
+	FIGHT_PROP_GRASS_ENERGY_COST FightProp = "FIGHT_PROP_GRASS_ENERGY_COST"
+	FIGHT_PROP_WIND_ENERGY_COST  FightProp = "FIGHT_PROP_WIND_ENERGY_COST"
+	FIGHT_PROP_ICE_ENERGY_COST   FightProp = "FIGHT_PROP_ICE_ENERGY_COST"
+	FIGHT_PROP_ROCK_ENERGY_COST  FightProp = "FIGHT_PROP_ROCK_ENERGY_COST"
+
+	// Current Energy Stats
+	FIGHT_PROP_CUR_FIRE_ENERGY  FightProp = "FIGHT_PROP_CUR_FIRE_ENERGY"
+	FIGHT_PROP_CUR_ELEC_ENERGY  FightProp = "FIGHT_PROP_CUR_ELEC_ENERGY"
+	FIGHT_PROP_CUR_WATER_ENERGY FightProp = "FIGHT_PROP_CUR_WATER_ENERGY"
+	FIGHT_PROP_CUR_GRASS_ENERGY FightProp = "FIGHT_PROP_CUR_GRASS_ENERGY"
+	FIGHT_PROP_CUR_WIND_ENERGY  FightProp = "FIGHT_PROP_CUR_WIND_ENERGY"
+	FIGHT_PROP_CUR_ICE_ENERGY   FightProp = "FIGHT_PROP_CUR_ICE_ENERGY"
+	FIGHT_PROP_CUR_ROCK_ENERGY  FightProp = "FIGHT_PROP_CUR_ROCK_ENERGY"
+
+	// Final Stats
+	FIGHT_PROP_CUR_HP      FightProp = "FIGHT_PROP_CUR_HP"
+	FIGHT_PROP_MAX_HP      FightProp = "FIGHT_PROP_MAX_HP"
+	FIGHT_PROP_CUR_ATTACK  FightProp = "FIGHT_PROP_CUR_ATTACK"
+	FIGHT_PROP_CUR_DEFENSE FightProp = "FIGHT_PROP_CUR_DEFENSE"
+	FIGHT_PROP_CUR_SPEED   FightProp = "FIGHT_PROP_CUR_SPEED"
+
+	// Bond of Life
+	FIGHT_PROP_CUR_HP_DEBTS FightProp = "FIGHT_PROP_CUR_HP_DEBTS"
+
+	// Non-extra Stats
+	FIGHT_PROP_NONEXTRA_SKILL_CD_MINUS_RATIO    FightProp = "FIGHT_PROP_NONEXTRA_SKILL_CD_MINUS_RATIO"
+	FIGHT_PROP_NONEXTRA_SHIELD_COST_MINUS_RATIO FightProp = "FIGHT_PROP_NONEXTRA_SHIELD_COST_MINUS_RATIO"
 )
 
 func (fp FightProp) String() string {
 	return string(fp)
 }
 
+// ID returns the numeric ID used for the prop in Enka's fightPropMap.
+// The second return value is false if the prop has no known numeric ID.
+func (fp FightProp) ID() (int, bool) {
+	id, ok := fightPropIDs[fp]
+	return id, ok
+}
+
+// FightPropFromID returns the FightProp registered for a numeric fightPropMap ID.
+// The second return value is false if the ID is unknown.
+func FightPropFromID(id int) (FightProp, bool) {
+	fp, ok := fightPropsByID[id]
+	return fp, ok
+}
+
 var FightPropMap = map[FightProp]string{
-	FIGHT_PROP_BASE_ATTACK:       "Base ATK",
-	FIGHT_PROP_HP:                "Flat HP",
-	FIGHT_PROP_ATTACK:            "Flat ATK",
-	FIGHT_PROP_DEFENSE:           "Flat DEF",
-	FIGHT_PROP_HP_PERCENT:        "HP%",
-	FIGHT_PROP_ATTACK_PERCENT:    "ATK%",
-	FIGHT_PROP_DEFENSE_PERCENT:   "DEF%",
-	FIGHT_PROP_CRITICAL:          "Crit RATE",
-	FIGHT_PROP_CRITICAL_HURT:     "Crit DMG",
-	FIGHT_PROP_CHARGE_EFFICIENCY: "Energy Recharge",
-	FIGHT_PROP_HEAL_ADD:          "Healing Bonus",
-	FIGHT_PROP_ELEMENT_MASTERY:   "Elemental Mastery",
-	FIGHT_PROP_PHYSICAL_ADD_HURT: "Physical DMG Bonus",
-	FIGHT_PROP_FIRE_ADD_HURT:     "Pyro DMG Bonus",
-	FIGHT_PROP_ELEC_ADD_HURT:     "Electro DMG Bonus",
-	FIGHT_PROP_WATER_ADD_HURT:    "Hydro DMG Bonus",
-	FIGHT_PROP_WIND_ADD_HURT:     "Anemo DMG Bonus",
-	FIGHT_PROP_ICE_ADD_HURT:      "Cryo DMG Bonus",
-	FIGHT_PROP_ROCK_ADD_HURT:     "Geo DMG Bonus",
-	FIGHT_PROP_GRASS_ADD_HURT:    "Dendro DMG Bonus",
+	FIGHT_PROP_BASE_HP:                          "Base HP",
+	FIGHT_PROP_BASE_ATTACK:                      "Base ATK",
+	FIGHT_PROP_BASE_DEFENSE:                     "Base DEF",
+	FIGHT_PROP_HP:                               "Flat HP",
+	FIGHT_PROP_ATTACK:                           "Flat ATK",
+	FIGHT_PROP_DEFENSE:                          "Flat DEF",
+	FIGHT_PROP_HP_PERCENT:                       "HP%",
+	FIGHT_PROP_ATTACK_PERCENT:                   "ATK%",
+	FIGHT_PROP_DEFENSE_PERCENT:                  "DEF%",
+	FIGHT_PROP_CRITICAL:                         "Crit RATE",
+	FIGHT_PROP_ANTI_CRITICAL:                    "Crit RES",
+	FIGHT_PROP_CRITICAL_HURT:                    "Crit DMG",
+	FIGHT_PROP_CHARGE_EFFICIENCY:                "Energy Recharge",
+	FIGHT_PROP_HEAL_ADD:                         "Healing Bonus",
+	FIGHT_PROP_HEALED_ADD:                       "Incoming Healing Bonus",
+	FIGHT_PROP_ELEMENT_MASTERY:                  "Elemental Mastery",
+	FIGHT_PROP_PHYSICAL_ADD_HURT:                "Physical DMG Bonus",
+	FIGHT_PROP_FIRE_ADD_HURT:                    "Pyro DMG Bonus",
+	FIGHT_PROP_ELEC_ADD_HURT:                    "Electro DMG Bonus",
+	FIGHT_PROP_WATER_ADD_HURT:                   "Hydro DMG Bonus",
+	FIGHT_PROP_WIND_ADD_HURT:                    "Anemo DMG Bonus",
+	FIGHT_PROP_ICE_ADD_HURT:                     "Cryo DMG Bonus",
+	FIGHT_PROP_ROCK_ADD_HURT:                    "Geo DMG Bonus",
+	FIGHT_PROP_GRASS_ADD_HURT:                   "Dendro DMG Bonus",
+	FIGHT_PROP_PHYSICAL_SUB_HURT:                "Physical RES",
+	FIGHT_PROP_FIRE_SUB_HURT:                    "Pyro RES",
+	FIGHT_PROP_ELEC_SUB_HURT:                    "Electro RES",
+	FIGHT_PROP_WATER_SUB_HURT:                   "Hydro RES",
+	FIGHT_PROP_GRASS_SUB_HURT:                   "Dendro RES",
+	FIGHT_PROP_WIND_SUB_HURT:                    "Anemo RES",
+	FIGHT_PROP_ROCK_SUB_HURT:                    "Geo RES",
+	FIGHT_PROP_ICE_SUB_HURT:                     "Cryo RES",
+	FIGHT_PROP_FIRE_ENERGY_COST:                 "Pyro Energy Cost",
+	FIGHT_PROP_ELEC_ENERGY_COST:                 "Electro Energy Cost",
+	FIGHT_PROP_WATER_ENERGY_COST:                "Hydro Energy Cost",
+	FIGHT_PROP_GRASS_ENERGY_COST:                "Dendro Energy Cost",
+	FIGHT_PROP_WIND_ENERGY_COST:                 "Anemo Energy Cost",
+	FIGHT_PROP_ICE_ENERGY_COST:                  "Cryo Energy Cost",
+	FIGHT_PROP_ROCK_ENERGY_COST:                 "Geo Energy Cost",
+	FIGHT_PROP_CUR_FIRE_ENERGY:                  "Current Pyro Energy",
+	FIGHT_PROP_CUR_ELEC_ENERGY:                  "Current Electro Energy",
+	FIGHT_PROP_CUR_WATER_ENERGY:                 "Current Hydro Energy",
+	FIGHT_PROP_CUR_GRASS_ENERGY:                 "Current Dendro Energy",
+	FIGHT_PROP_CUR_WIND_ENERGY:                  "Current Anemo Energy",
+	FIGHT_PROP_CUR_ICE_ENERGY:                   "Current Cryo Energy",
+	FIGHT_PROP_CUR_ROCK_ENERGY:                  "Current Geo Energy",
+	FIGHT_PROP_CUR_HP:                           "Current HP",
+	FIGHT_PROP_MAX_HP:                           "Max HP",
+	FIGHT_PROP_CUR_ATTACK:                       "ATK",
+	FIGHT_PROP_CUR_DEFENSE:                      "DEF",
+	FIGHT_PROP_CUR_SPEED:                        "Speed",
+	FIGHT_PROP_CUR_HP_DEBTS:                     "Bond of Life",
+	FIGHT_PROP_NONEXTRA_SKILL_CD_MINUS_RATIO:    "CD Reduction",
+	FIGHT_PROP_NONEXTRA_SHIELD_COST_MINUS_RATIO: "Shield Cost Reduction",
 }
+
+// fightPropsByID maps the numeric keys of Enka's fightPropMap to their FightProp,
+// see FightPropFromID and FightProp.ID
+var fightPropsByID = map[int]FightProp{
+	1:    FIGHT_PROP_BASE_HP,
+	2:    FIGHT_PROP_HP,
+	3:    FIGHT_PROP_HP_PERCENT,
+	4:    FIGHT_PROP_BASE_ATTACK,
+	5:    FIGHT_PROP_ATTACK,
+	6:    FIGHT_PROP_ATTACK_PERCENT,
+	7:    FIGHT_PROP_BASE_DEFENSE,
+	8:    FIGHT_PROP_DEFENSE,
+	9:    FIGHT_PROP_DEFENSE_PERCENT,
+	20:   FIGHT_PROP_CRITICAL,
+	21:   FIGHT_PROP_ANTI_CRITICAL,
+	22:   FIGHT_PROP_CRITICAL_HURT,
+	23:   FIGHT_PROP_CHARGE_EFFICIENCY,
+	26:   FIGHT_PROP_HEAL_ADD,
+	27:   FIGHT_PROP_HEALED_ADD,
+	28:   FIGHT_PROP_ELEMENT_MASTERY,
+	29:   FIGHT_PROP_PHYSICAL_SUB_HURT,
+	30:   FIGHT_PROP_PHYSICAL_ADD_HURT,
+	40:   FIGHT_PROP_FIRE_ADD_HURT,
+	41:   FIGHT_PROP_ELEC_ADD_HURT,
+	42:   FIGHT_PROP_WATER_ADD_HURT,
+	43:   FIGHT_PROP_GRASS_ADD_HURT,
+	44:   FIGHT_PROP_WIND_ADD_HURT,
+	45:   FIGHT_PROP_ROCK_ADD_HURT,
+	46:   FIGHT_PROP_ICE_ADD_HURT,
+	50:   FIGHT_PROP_FIRE_SUB_HURT,
+	51:   FIGHT_PROP_ELEC_SUB_HURT,
+	52:   FIGHT_PROP_WATER_SUB_HURT,
+	53:   FIGHT_PROP_GRASS_SUB_HURT,
+	54:   FIGHT_PROP_WIND_SUB_HURT,
+	55:   FIGHT_PROP_ROCK_SUB_HURT,
+	56:   FIGHT_PROP_ICE_SUB_HURT,
+	70:   FIGHT_PROP_FIRE_ENERGY_COST,
+	71:   FIGHT_PROP_ELEC_ENERGY_COST,
+	72:   FIGHT_PROP_WATER_ENERGY_COST,
+	73:   FIGHT_PROP_GRASS_ENERGY_COST,
+	74:   FIGHT_PROP_WIND_ENERGY_COST,
+	75:   FIGHT_PROP_ICE_ENERGY_COST,
+	76:   FIGHT_PROP_ROCK_ENERGY_COST,
+	1000: FIGHT_PROP_CUR_FIRE_ENERGY,
+	1001: FIGHT_PROP_CUR_ELEC_ENERGY,
+	1002: FIGHT_PROP_CUR_WATER_ENERGY,
+	1003: FIGHT_PROP_CUR_GRASS_ENERGY,
+	1004: FIGHT_PROP_CUR_WIND_ENERGY,
+	1005: FIGHT_PROP_CUR_ICE_ENERGY,
+	1006: FIGHT_PROP_CUR_ROCK_ENERGY,
+	1010: FIGHT_PROP_CUR_HP,
+	2000: FIGHT_PROP_MAX_HP,
+	2001: FIGHT_PROP_CUR_ATTACK,
+	2002: FIGHT_PROP_CUR_DEFENSE,
+	2003: FIGHT_PROP_CUR_SPEED,
+	2004: FIGHT_PROP_CUR_HP_DEBTS,
+	3045: FIGHT_PROP_NONEXTRA_SKILL_CD_MINUS_RATIO,
+	3046: FIGHT_PROP_NONEXTRA_SHIELD_COST_MINUS_RATIO,
+}
+
+// fightPropIDs is the reverse lookup of fightPropsByID
+var fightPropIDs = func() map[FightProp]int {
+	ids := make(map[FightProp]int, len(fightPropsByID))
+	for id, fp := range fightPropsByID {
+		ids[fp] = id
+	}
+	return ids
+}()
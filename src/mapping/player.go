@@ -0,0 +1,31 @@
+package mapping
+
+// PlayerProp is the numeric type of an entry in Enka's propMap.
+type PlayerProp int
+
+const (
+	PROP_EXP                    PlayerProp = 1001
+	PROP_BREAK_LEVEL            PlayerProp = 1002
+	PROP_GEAR_START_VAL         PlayerProp = 1003
+	PROP_GEAR_STOP_VAL          PlayerProp = 1004
+	PROP_LEVEL                  PlayerProp = 4001
+	PROP_SATIATION_VAL          PlayerProp = 10010
+	PROP_SATIATION_PENALTY_TIME PlayerProp = 10049
+)
+
+func (pp PlayerProp) String() string {
+	if name, ok := PlayerPropMap[pp]; ok {
+		return name
+	}
+	return "PROP_UNKNOWN"
+}
+
+var PlayerPropMap = map[PlayerProp]string{
+	PROP_EXP:                    "PROP_EXP",
+	PROP_BREAK_LEVEL:            "PROP_BREAK_LEVEL",
+	PROP_GEAR_START_VAL:         "PROP_GEAR_START_VAL",
+	PROP_GEAR_STOP_VAL:          "PROP_GEAR_STOP_VAL",
+	PROP_LEVEL:                  "PROP_LEVEL",
+	PROP_SATIATION_VAL:          "PROP_SATIATION_VAL",
+	PROP_SATIATION_PENALTY_TIME: "PROP_SATIATION_PENALTY_TIME",
+}
@@ -0,0 +1,282 @@
+package data
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/calc"
+	"github.com/utkarsh5026/Genka/src/client"
+	"github.com/utkarsh5026/Genka/src/data"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func newTestCharacterCalculator() *calc.CharacterCalculator {
+	avatars := []data.AvatarConfig{{
+		ID:              10000060,
+		AvatarPromoteID: 60,
+		HpBase:          1125,
+		AttackBase:      19,
+		DefenseBase:     43,
+		PropGrowCurves: []data.PropGrowCurve{
+			{Type: mapping.FIGHT_PROP_BASE_HP, GrowCurve: "GROW_CURVE_HP_S5"},
+			{Type: mapping.FIGHT_PROP_BASE_ATTACK, GrowCurve: "GROW_CURVE_ATTACK_S5"},
+			{Type: mapping.FIGHT_PROP_BASE_DEFENSE, GrowCurve: "GROW_CURVE_HP_S5"},
+		},
+	}}
+
+	curve := func(level int, hp, atk float64) data.AvatarCurve {
+		return data.AvatarCurve{Level: level, CurveInfos: []data.CurveInfo{
+			{Type: "GROW_CURVE_HP_S5", Arith: "ARITH_MULTI", Value: hp},
+			{Type: "GROW_CURVE_ATTACK_S5", Arith: "ARITH_MULTI", Value: atk},
+		}}
+	}
+	curves := []data.AvatarCurve{curve(1, 1, 1), curve(20, 2.5, 2.6), curve(80, 7.5, 7.7), curve(90, 8.2, 8.4)}
+
+	promote := func(level, maxLevel int, hp, crit float64) data.AvatarPromote {
+		return data.AvatarPromote{AvatarPromoteID: 60, PromoteLevel: level, UnlockMaxLevel: maxLevel, AddProps: []data.PropEntry{
+			{PropType: mapping.FIGHT_PROP_BASE_HP, Value: hp},
+			{PropType: mapping.FIGHT_PROP_BASE_DEFENSE, Value: hp / 10},
+			{PropType: mapping.FIGHT_PROP_BASE_ATTACK, Value: hp / 100},
+			{PropType: mapping.FIGHT_PROP_CRITICAL, Value: crit},
+		}}
+	}
+	// out of order on purpose
+	promotes := []data.AvatarPromote{
+		promote(6, 90, 4000, 0.192), promote(0, 20, 0, 0), promote(1, 40, 900, 0),
+		promote(2, 50, 1500, 0.048), promote(3, 60, 2400, 0.096), promote(4, 70, 3000, 0.096),
+		promote(5, 80, 3500, 0.144),
+	}
+	return calc.NewCharacterCalculator(avatars, curves, promotes)
+}
+
+func TestCharacterBaseStats(t *testing.T) {
+	c := newTestCharacterCalculator()
+
+	stats, err := c.BaseStats(10000060, 90, 6)
+	if err != nil {
+		t.Fatalf("Failed to compute stats: %v", err)
+	}
+	if !almostEqual(stats.HP, 1125*8.2+4000) || !almostEqual(stats.ATK, 19*8.4+40) || !almostEqual(stats.DEF, 43*8.2+400) {
+		t.Errorf("Unexpected base stats %+v", stats)
+	}
+	if stats.AscensionProp != mapping.FIGHT_PROP_CRITICAL || !almostEqual(stats.AscensionValue, 0.192) {
+		t.Errorf("Unexpected ascension stat %s=%v", stats.AscensionProp, stats.AscensionValue)
+	}
+
+	// level 80 is valid both before and after the sixth ascension
+	if _, err := c.BaseStats(10000060, 80, 5); err != nil {
+		t.Errorf("Expected 80/80 to be valid, got %v", err)
+	}
+	if _, err := c.BaseStats(10000060, 80, 6); err != nil {
+		t.Errorf("Expected 80/90 to be valid, got %v", err)
+	}
+
+	if _, err := c.BaseStats(10000060, 90, 5); !errors.Is(err, calc.ErrInvalidLevel) {
+		t.Errorf("Expected ErrInvalidLevel for 90 at ascension 5, got %v", err)
+	}
+	if _, err := c.BaseStats(10000060, 20, 7); !errors.Is(err, calc.ErrUnknownPromote) {
+		t.Errorf("Expected ErrUnknownPromote for ascension 7, got %v", err)
+	}
+	if _, err := c.BaseStats(10000002, 1, 0); !errors.Is(err, calc.ErrUnknownItem) {
+		t.Errorf("Expected ErrUnknownItem, got %v", err)
+	}
+}
+
+func newTestWeaponCalculator() *calc.WeaponCalculator {
+	weapons := []data.WeaponConfig{{
+		ID:              15401,
+		WeaponPromoteID: 15401,
+		SkillAffix:      []int{115401, 0},
+		WeaponProp: []data.WeaponProp{
+			{PropType: mapping.FIGHT_PROP_BASE_ATTACK, InitValue: 42.4, Type: "GROW_CURVE_ATTACK_201"},
+			{PropType: mapping.FIGHT_PROP_CHARGE_EFFICIENCY, InitValue: 0.133, Type: "GROW_CURVE_CRITICAL_201"},
+		},
+	}}
+	curves := []data.WeaponCurve{
+		{Level: 1, CurveInfos: []data.CurveInfo{
+			{Type: "GROW_CURVE_ATTACK_201", Arith: "ARITH_MULTI", Value: 1},
+			{Type: "GROW_CURVE_CRITICAL_201", Arith: "ARITH_MULTI", Value: 1},
+		}},
+		{Level: 90, CurveInfos: []data.CurveInfo{
+			{Type: "GROW_CURVE_ATTACK_201", Arith: "ARITH_MULTI", Value: 7.7},
+			{Type: "GROW_CURVE_CRITICAL_201", Arith: "ARITH_MULTI", Value: 4.6},
+		}},
+	}
+	var promotes []data.WeaponPromote
+	for i, maxLevel := range []int{20, 40, 50, 60, 70, 80, 90} {
+		promotes = append(promotes, data.WeaponPromote{
+			WeaponPromoteID: 15401, PromoteLevel: i, UnlockMaxLevel: maxLevel,
+			AddProps: []data.PropEntry{{PropType: mapping.FIGHT_PROP_BASE_ATTACK, Value: float64(i) * 20}},
+		})
+	}
+	var affixes []data.EquipAffix
+	for level := 0; level < 5; level++ {
+		affixes = append(affixes, data.EquipAffix{
+			AffixID: 1154010 + level, ID: 115401, Level: level,
+			AddProps: []data.PropEntry{{PropType: mapping.FIGHT_PROP_HEAL_ADD, Value: 0.1 + float64(level)*0.025}},
+		})
+	}
+	return calc.NewWeaponCalculator(weapons, curves, promotes, affixes)
+}
+
+func TestWeaponStats(t *testing.T) {
+	c := newTestWeaponCalculator()
+
+	stats, err := c.Stats(15401, 90, 6, 5)
+	if err != nil {
+		t.Fatalf("Failed to compute stats: %v", err)
+	}
+	if !almostEqual(stats.BaseATK, 42.4*7.7+120) {
+		t.Errorf("Expected base ATK %v, got %v", 42.4*7.7+120, stats.BaseATK)
+	}
+	if stats.SubProp != mapping.FIGHT_PROP_CHARGE_EFFICIENCY || !almostEqual(stats.SubValue, 0.133*4.6) {
+		t.Errorf("Unexpected secondary stat %s=%v", stats.SubProp, stats.SubValue)
+	}
+	if stats.Affix == nil || stats.Affix.AffixID != 1154014 {
+		t.Fatalf("Expected the R5 affix, got %+v", stats.Affix)
+	}
+	if !almostEqual(stats.PassiveProps[mapping.FIGHT_PROP_HEAL_ADD], 0.2) {
+		t.Errorf("Unexpected passive props %v", stats.PassiveProps)
+	}
+
+	if _, err := c.Stats(15401, 90, 6, 6); !errors.Is(err, calc.ErrInvalidLevel) {
+		t.Errorf("Expected ErrInvalidLevel for R6, got %v", err)
+	}
+}
+
+func TestWeaponEquipmentStats(t *testing.T) {
+	c := newTestWeaponCalculator()
+	profile := loadFixtureProfile(t)
+
+	for _, equip := range profile.AvatarInfoList[0].EquipList {
+		if !equip.IsWeapon() {
+			continue
+		}
+		stats, err := c.EquipmentStats(equip)
+		if err != nil {
+			t.Fatalf("Failed to compute stats: %v", err)
+		}
+		if stats.Refinement != 1 || stats.Affix.Level != 0 {
+			t.Errorf("Expected R1, got R%d", stats.Refinement)
+		}
+	}
+}
+
+func newTestArtifactCalculator() *calc.ArtifactCalculator {
+	reliquaries := []data.ReliquaryConfig{{ID: 94543, RankLevel: 5, MainPropDepotID: 4000, AppendPropDepotID: 501}}
+	mainProps := []data.ReliquaryMainProp{
+		{ID: 14001, PropDepotID: 4000, PropType: mapping.FIGHT_PROP_HP},
+		{ID: 10001, PropDepotID: 1000, PropType: mapping.FIGHT_PROP_ATTACK},
+	}
+	levels := []data.ReliquaryLevel{{Level: 1, Exp: 3000}}
+	for level := 1; level <= 21; level++ {
+		levels = append(levels, data.ReliquaryLevel{Rank: 5, Level: level, AddProps: []data.PropEntry{
+			{PropType: mapping.FIGHT_PROP_HP, Value: 717 + float64(level-1)*203.15},
+			{PropType: mapping.FIGHT_PROP_ATTACK, Value: 47 + float64(level-1)*13.2},
+		}})
+	}
+	return calc.NewArtifactCalculator(reliquaries, mainProps, levels)
+}
+
+func TestArtifactMainStat(t *testing.T) {
+	c := newTestArtifactCalculator()
+
+	if c.MaxLevel(5) != 21 {
+		t.Errorf("Expected max level 21, got %d", c.MaxLevel(5))
+	}
+
+	profile := loadFixtureProfile(t)
+	equip := profile.AvatarInfoList[0].EquipList[0]
+	// drop the flat block like a saved build would
+	equip.Flat = client.Flat{}
+
+	stat, err := c.EquipmentMainStat(equip)
+	if err != nil {
+		t.Fatalf("Failed to resolve main stat: %v", err)
+	}
+	if stat.Prop != mapping.FIGHT_PROP_HP || !almostEqual(stat.Value, 4780) {
+		t.Errorf("Expected 4780 HP, got %s=%v", stat.Prop, stat.Value)
+	}
+
+	curve, err := c.MainStatCurve(10001, 5)
+	if err != nil {
+		t.Fatalf("Failed to resolve main stat curve: %v", err)
+	}
+	if len(curve) != 21 || !almostEqual(curve[0].Value, 47) || !almostEqual(curve[20].Value, 311) {
+		t.Errorf("Unexpected curve %+v", curve)
+	}
+
+	if _, err := c.MainStat(14001, 5, 22); !errors.Is(err, calc.ErrInvalidLevel) {
+		t.Errorf("Expected ErrInvalidLevel, got %v", err)
+	}
+	if _, err := c.MainStat(99999, 5, 1); !errors.Is(err, calc.ErrUnknownItem) {
+		t.Errorf("Expected ErrUnknownItem, got %v", err)
+	}
+}
+
+func TestSubstatDecoder(t *testing.T) {
+	var affixes []data.ReliquaryAffix
+	add := func(group int, prop mapping.FightProp, max float64) {
+		for tier := 1; tier <= 4; tier++ {
+			affixes = append(affixes, data.ReliquaryAffix{
+				ID: 501000 + group*10 + tier, DepotID: 501, PropType: prop,
+				PropValue: max * (0.6 + 0.1*float64(tier)),
+			})
+		}
+	}
+	add(3, mapping.FIGHT_PROP_HP_PERCENT, 0.0583)
+	add(6, mapping.FIGHT_PROP_ATTACK_PERCENT, 0.0583)
+	add(22, mapping.FIGHT_PROP_CRITICAL_HURT, 0.0777)
+	add(23, mapping.FIGHT_PROP_CHARGE_EFFICIENCY, 0.0648)
+	d := calc.NewSubstatDecoder(affixes)
+
+	profile := loadFixtureProfile(t)
+	breakdown, err := d.DecodeEquipment(profile.AvatarInfoList[0].EquipList[0])
+	if err != nil {
+		t.Fatalf("Failed to decode substats: %v", err)
+	}
+
+	if breakdown.InitialRolls != 3 || breakdown.UpgradeRolls != 5 {
+		t.Errorf("Expected 3 initial and 5 upgrade rolls, got %d and %d", breakdown.InitialRolls, breakdown.UpgradeRolls)
+	}
+
+	want := []struct {
+		prop     mapping.FightProp
+		tiers    []calc.RollTier
+		upgrades int
+	}{
+		{mapping.FIGHT_PROP_HP_PERCENT, []calc.RollTier{calc.TierMax}, 0},
+		{mapping.FIGHT_PROP_CRITICAL_HURT, []calc.RollTier{calc.TierMax, calc.TierHigh, calc.TierMid}, 2},
+		{mapping.FIGHT_PROP_ATTACK_PERCENT, []calc.RollTier{calc.TierHigh, calc.TierMax}, 1},
+		{mapping.FIGHT_PROP_CHARGE_EFFICIENCY, []calc.RollTier{calc.TierLow, calc.TierMid}, 2},
+	}
+	if len(breakdown.Substats) != len(want) {
+		t.Fatalf("Expected %d substats, got %d", len(want), len(breakdown.Substats))
+	}
+	for i, w := range want {
+		substat := breakdown.Substats[i]
+		if substat.Prop != w.prop || len(substat.Rolls) != len(w.tiers) || substat.Upgrades() != w.upgrades {
+			t.Errorf("Unexpected substat %d: %+v", i, substat)
+			continue
+		}
+		for j, tier := range w.tiers {
+			if substat.Rolls[j].Tier != tier {
+				t.Errorf("%s roll %d: expected %s, got %s", w.prop, j, tier, substat.Rolls[j].Tier)
+			}
+		}
+	}
+
+	// the flat block of the fixture agrees with the decoded values
+	if cd := breakdown.Substats[1].Value * 100; math.Abs(cd-21) > 0.05 {
+		t.Errorf("Expected about 21%% crit DMG, got %v", cd)
+	}
+
+	if _, err := d.Decode([]int{501034}, 21); !errors.Is(err, calc.ErrInvalidLevel) {
+		t.Errorf("Expected ErrInvalidLevel, got %v", err)
+	}
+}
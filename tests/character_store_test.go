@@ -0,0 +1,112 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/data"
+)
+
+// loadCharacterStoreSources parses res/character-store-excel.json, which holds
+// the Excel records of a few characters keyed by their file name
+func loadCharacterStoreSources(t *testing.T) data.CharacterStoreSources {
+	t.Helper()
+	raw, err := os.ReadFile("../res/character-store-excel.json")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	var files map[data.GenshinDataFileName]json.RawMessage
+	if err := json.Unmarshal(raw, &files); err != nil {
+		t.Fatalf("Failed to decode fixture: %v", err)
+	}
+
+	var src data.CharacterStoreSources
+	if src.Avatars, err = data.ParseAvatarConfigs(files[data.CharacterDataFile]); err != nil {
+		t.Fatal(err)
+	}
+	if src.SkillDepots, err = data.ParseAvatarSkillDepots(files[data.CharacterSkillDepotFile]); err != nil {
+		t.Fatal(err)
+	}
+	if src.Skills, err = data.ParseAvatarSkills(files[data.CharacterSkillFile]); err != nil {
+		t.Fatal(err)
+	}
+	if src.Talents, err = data.ParseAvatarTalents(files[data.CharacterConstellationFile]); err != nil {
+		t.Fatal(err)
+	}
+	if src.Costumes, err = data.ParseAvatarCostumes(files[data.CharacterCostumeFile]); err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func TestGenerateCharacterStore(t *testing.T) {
+	store, err := data.GenerateCharacterStore(loadCharacterStoreSources(t))
+	if err != nil {
+		t.Fatalf("Failed to generate store: %v", err)
+	}
+
+	// the golden file holds the entries of res/characters.json for the fixture
+	// characters, with the NameTextMapHash the generator gives the element-less
+	// Traveler, see GenerateCharacterStore
+	raw, err := os.ReadFile("../res/character-store-golden.json")
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	var golden map[string]interface{}
+	if err := json.Unmarshal(raw, &golden); err != nil {
+		t.Fatalf("Failed to decode golden file: %v", err)
+	}
+
+	encoded, err := json.Marshal(store)
+	if err != nil {
+		t.Fatalf("Failed to encode store: %v", err)
+	}
+	var generated map[string]interface{}
+	if err := json.Unmarshal(encoded, &generated); err != nil {
+		t.Fatalf("Failed to decode generated store: %v", err)
+	}
+
+	for key, want := range golden {
+		if got, ok := generated[key]; !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("Entry %s does not match the golden file\ngot:  %v\nwant: %v", key, got, want)
+		}
+	}
+	for key := range generated {
+		if _, ok := golden[key]; !ok {
+			t.Errorf("Unexpected entry %s", key)
+		}
+	}
+}
+
+func TestGenerateCharacterStoreShortDepot(t *testing.T) {
+	store, err := data.GenerateCharacterStore(data.CharacterStoreSources{
+		Avatars: []data.AvatarConfig{{
+			ID:                10000007,
+			UseType:           "AVATAR_FORMAL",
+			SkillDepotID:      701,
+			CandSkillDepotIDs: []int{704},
+		}},
+		SkillDepots: []data.AvatarSkillDepot{
+			{ID: 701, EnergySkill: 10068},
+			{ID: 704, EnergySkill: 10068, Skills: []int{100556}},
+		},
+		Skills: []data.AvatarSkill{
+			{ID: 10068, SkillIcon: "Skill_E_PlayerWind_01", CostElemType: "Wind"},
+			{ID: 100556, SkillIcon: "Skill_A_01"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate store: %v", err)
+	}
+
+	start := store["10000007-701"]
+	if !reflect.DeepEqual(start.SkillOrder, []int{10068}) || start.Skills[10068] != "None" {
+		t.Errorf("Expected only the burst without its icon, got %v %v", start.SkillOrder, start.Skills)
+	}
+	anemo := store["10000007-704"]
+	if !reflect.DeepEqual(anemo.SkillOrder, []int{100556, 10068}) || anemo.Skills[10068] != "Skill_E_PlayerWind_01" {
+		t.Errorf("Expected the normal attack and the burst, got %v %v", anemo.SkillOrder, anemo.Skills)
+	}
+}
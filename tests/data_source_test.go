@@ -0,0 +1,459 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/utkarsh5026/Genka/src/data"
+)
+
+// newDataServer serves files below /{ref}/ with a fixed ETag and answers
+// If-None-Match with 304. downloads counts the 200 responses.
+func newDataServer(t *testing.T, files map[string]string, downloads *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(downloads, 1)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSyncDataFiles(t *testing.T) {
+	var downloads int32
+	server := newDataServer(t, map[string]string{
+		"/v5.0/ExcelBinOutput/RoleCombatDifficultyExcelConfigData.json": `[{"difficultyId": 1}]`,
+	}, &downloads)
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	source := data.NewHTTPSource(server.URL+"/{ref}/{path}", "v5.0")
+	rl := data.NewResourceLoader(fm, false, data.WithSource(source))
+	files := []data.GenshinDataFileName{data.TheaterDifficultyFile}
+
+	report, err := rl.SyncDataFiles(files)
+	if err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if len(report.Updated()) != 1 || len(report.Skipped()) != 0 {
+		t.Errorf("Expected one updated file, got %+v", report)
+	}
+
+	report, err = rl.SyncDataFiles(files)
+	if err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if len(report.Updated()) != 0 || len(report.Skipped()) != 1 {
+		t.Errorf("Expected one unchanged file, got %+v", report)
+	}
+	if got := atomic.LoadInt32(&downloads); got != 1 {
+		t.Errorf("Expected 1 download, got %d", got)
+	}
+
+	// a corrupt copy of the same size must not be reported as up to date
+	filePath := filepath.Join(rl.GetDataDirPath(), "RoleCombatDifficultyExcelConfigData.json")
+	if err := os.WriteFile(filePath, []byte(`[{"difficultyId": 2}]`), 0644); err != nil {
+		t.Fatalf("Failed to corrupt file: %v", err)
+	}
+	report, err = rl.SyncDataFiles(files)
+	if err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if len(report.Updated()) != 1 || atomic.LoadInt32(&downloads) != 2 {
+		t.Errorf("Expected the corrupt file to be downloaded again, got %+v", report)
+	}
+	if raw, err := fm.LoadFile(data.TheaterDifficultyFile); err != nil || string(raw) != `[{"difficultyId": 1}]` {
+		t.Errorf("Expected the file to be repaired, got %s: %v", raw, err)
+	}
+
+	manifest, err := fm.LoadManifest()
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	entry := manifest.Files["data/RoleCombatDifficultyExcelConfigData.json"]
+	if entry.ETag != `"v1"` || entry.Ref != "v5.0" || entry.Source != source.URL("ExcelBinOutput/RoleCombatDifficultyExcelConfigData.json") {
+		t.Errorf("Unexpected manifest entry %+v", entry)
+	}
+}
+
+func TestLoadLangFilesProgress(t *testing.T) {
+	body := `{"1001": "Traveler", "1002": "Paimon"}`
+	var downloads int32
+	server := newDataServer(t, map[string]string{
+		"/master/TextMap/TextMapTH.json": body,
+		"/master/TextMap/TextMapVI.json": `{"1001": "truncated`,
+	}, &downloads)
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	var mu sync.Mutex
+	var last data.Progress
+	rl := data.NewResourceLoader(fm, false,
+		data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master")),
+		data.WithProgress(func(p data.Progress) {
+			mu.Lock()
+			last = p
+			mu.Unlock()
+		}),
+	)
+
+	if err := rl.LoadLangFiles([]data.Language{data.LangThai}); err != nil {
+		t.Fatalf("Failed to load lang files: %v", err)
+	}
+	want := data.Progress{File: "langs/th.json", Done: int64(len(body)), Total: int64(len(body))}
+	if last != want {
+		t.Errorf("Expected final progress %+v, got %+v", want, last)
+	}
+	if got, err := fm.LoadLangFile(data.LangThai); err != nil || string(got) != body {
+		t.Errorf("Unexpected saved file %s: %v", got, err)
+	}
+
+	if err := rl.LoadLangFiles([]data.Language{data.LangVietnamese}); err == nil {
+		t.Fatal("Expected an error for a truncated TextMap")
+	}
+	langDir := filepath.Join(filepath.Dir(fm.ManifestPath()), "langs")
+	entries, err := os.ReadDir(langDir)
+	if err != nil {
+		t.Fatalf("Failed to list lang directory: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == "vi.json" || strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("Unexpected file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestSyncSaveModes(t *testing.T) {
+	var downloads int32
+	server := newDataServer(t, map[string]string{
+		"/master/ExcelBinOutput/AvatarCodexExcelConfigData.json": `[{"sortId": 1}]`,
+	}, &downloads)
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	files := []data.GenshinDataFileName{data.CharacterReleaseInfoFile, data.WeaponReleaseInfoFile}
+	source := data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master"))
+
+	rl := data.NewResourceLoader(fm, false, source, data.WithConcurrency(1))
+	report, err := rl.SyncDataFiles(files)
+	if !errors.Is(err, data.ErrSourceFileNotFound) {
+		t.Errorf("Expected ErrSourceFileNotFound, got %v", err)
+	}
+	if len(report.Failed()) != 2 || !errors.Is(report.Results[0].Err, data.ErrBatchAborted) {
+		t.Errorf("Expected both files to fail, got %+v", report.Results)
+	}
+	if _, err := fm.LoadFile(data.CharacterReleaseInfoFile); !os.IsNotExist(err) {
+		t.Errorf("Expected %s not to be saved, got %v", data.CharacterReleaseInfoFile, err)
+	}
+
+	rl = data.NewResourceLoader(fm, false, source, data.WithSaveMode(data.SaveIndependent))
+	report, err = rl.SyncDataFiles(files)
+	if err == nil {
+		t.Error("Expected an error for the missing file")
+	}
+	if got := report.Updated(); len(got) != 1 || got[0] != "data/AvatarCodexExcelConfigData.json" {
+		t.Errorf("Expected the codex to be updated, got %v", got)
+	}
+	if got := report.Failed(); len(got) != 1 || got[0] != "data/WeaponCodexExcelConfigData.json" {
+		t.Errorf("Expected the weapon codex to fail, got %v", got)
+	}
+	if _, err := fm.LoadFile(data.CharacterReleaseInfoFile); err != nil {
+		t.Errorf("Expected %s to be saved, got %v", data.CharacterReleaseInfoFile, err)
+	}
+}
+
+func TestSyncCorruptManifest(t *testing.T) {
+	var downloads int32
+	server := newDataServer(t, map[string]string{
+		"/master/ExcelBinOutput/AvatarCodexExcelConfigData.json": `[{"sortId": 1}]`,
+	}, &downloads)
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	if err := os.WriteFile(fm.ManifestPath(), []byte(`{bad`), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	rl := data.NewResourceLoader(fm, false, data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master")))
+	report, err := rl.SyncDataFiles([]data.GenshinDataFileName{data.CharacterReleaseInfoFile, data.WeaponReleaseInfoFile})
+	if err == nil || !strings.Contains(err.Error(), "failed to decode manifest") {
+		t.Errorf("Expected a manifest error, got %v", err)
+	}
+	if len(report.Updated()) != 0 || len(report.Failed()) != 2 || report.Results[1].Err == nil {
+		t.Errorf("Expected both files to fail with the manifest error, got %+v", report.Results)
+	}
+	if got := atomic.LoadInt32(&downloads); got != 0 {
+		t.Errorf("Expected no download, got %d", got)
+	}
+}
+
+func TestLoadDataFilesWithReport(t *testing.T) {
+	var downloads int32
+	server := newDataServer(t, map[string]string{
+		"/master/ExcelBinOutput/AvatarCodexExcelConfigData.json": `[{"sortId": 1}]`,
+	}, &downloads)
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	rl := data.NewResourceLoader(fm, false,
+		data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master")),
+		data.WithSaveMode(data.SaveIndependent),
+	)
+
+	files := []data.GenshinDataFileName{data.CharacterReleaseInfoFile, data.WeaponReleaseInfoFile}
+	report, err := rl.LoadDataFilesWithReport(files)
+	if !errors.Is(err, data.ErrSourceFileNotFound) {
+		t.Errorf("Expected ErrSourceFileNotFound, got %v", err)
+	}
+	if len(report.Results) != 2 || report.Results[0].Status != data.FileUpdated || report.Results[1].Status != data.FileFailed {
+		t.Errorf("Expected the codex to be updated and the weapon codex to fail, got %+v", report.Results)
+	}
+	if !errors.Is(report.Results[1].Err, data.ErrSourceFileNotFound) {
+		t.Errorf("Expected the cause of the failure, got %v", report.Results[1].Err)
+	}
+}
+
+// newStuckServer sends the start of a body and then hangs until the client goes away
+func newStuckServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		_, _ = io.WriteString(w, `[{"id": 1},`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// assertNoTempFiles fails when a download left a temporary file in the data directory
+func assertNoTempFiles(t *testing.T, fm *data.FileManager) {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Join(filepath.Dir(fm.ManifestPath()), "data"))
+	if err != nil {
+		t.Fatalf("Failed to list data directory: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("Temporary file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestLoadDataFilesCancel(t *testing.T) {
+	server := newStuckServer(t)
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	rl := data.NewResourceLoader(fm, false, data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	err = rl.LoadDataFilesContext(ctx, []data.GenshinDataFileName{data.MaterialDataFile, data.TravelerDataFile})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if _, err := fm.LoadFile(data.TravelerDataFile); !os.IsNotExist(err) {
+		t.Errorf("Expected %s not to be saved, got %v", data.TravelerDataFile, err)
+	}
+	assertNoTempFiles(t, fm)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := newStuckServer(t)
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	rl := data.NewResourceLoader(fm, false,
+		data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master")),
+		data.WithRequestTimeout(50*time.Millisecond),
+	)
+
+	start := time.Now()
+	_, err = rl.SyncDataFiles([]data.GenshinDataFileName{data.TravelerDataFile})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Timeout took %v", elapsed)
+	}
+	assertNoTempFiles(t, fm)
+}
+
+func TestLoaderLogging(t *testing.T) {
+	var downloads int32
+	server := newDataServer(t, map[string]string{
+		"/master/TextMap/TextMapID.json": `{"1001": "Pengembara"}`,
+	}, &downloads)
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	rl := data.NewResourceLoader(fm, false,
+		data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master")),
+		data.WithLogger(logger),
+	)
+	if err := rl.LoadLangFiles([]data.Language{data.LangIndonesian}); err != nil {
+		t.Fatalf("Failed to load lang files: %v", err)
+	}
+
+	var downloaded map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid log line %q: %v", line, err)
+		}
+		if record["msg"] == "file downloaded" {
+			downloaded = record
+		}
+	}
+	if downloaded == nil {
+		t.Fatalf("No download record in %s", buf.String())
+	}
+	for _, key := range []string{"file", "url", "bytes", "duration", "language"} {
+		if _, ok := downloaded[key]; !ok {
+			t.Errorf("Record %v is missing %s", downloaded, key)
+		}
+	}
+	if downloaded["language"] != "id" || downloaded["file"] != "langs/id.json" {
+		t.Errorf("Unexpected record %v", downloaded)
+	}
+}
+
+func TestFallbackSource(t *testing.T) {
+	var downloads int32
+	server := newDataServer(t, map[string]string{}, &downloads)
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "ExcelBinOutput"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	contents := `[{"id": 7}]`
+	if err := os.WriteFile(filepath.Join(root, "ExcelBinOutput", "FetterCharacterCardExcelConfigData.json"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	source := data.NewFallbackSource(
+		data.NewHTTPSource(server.URL+"/{ref}/{path}", "master"),
+		data.NewDirSource(root),
+	)
+	rl := data.NewResourceLoader(fm, false, data.WithSource(source))
+
+	if err := rl.LoadDataFiles([]data.GenshinDataFileName{data.FriendshipRewardFile}); err != nil {
+		t.Fatalf("Failed to load data files: %v", err)
+	}
+	got, err := fm.LoadFile(data.FriendshipRewardFile)
+	if err != nil {
+		t.Fatalf("Failed to read saved file: %v", err)
+	}
+	if string(got) != contents {
+		t.Errorf("Expected %s, got %s", contents, got)
+	}
+
+	_, err = source.Open(context.Background(), "ExcelBinOutput/Missing.json", "")
+	if !errors.Is(err, data.ErrSourceFileNotFound) {
+		t.Errorf("Expected ErrSourceFileNotFound, got %v", err)
+	}
+}
+
+func TestGitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	if err := os.MkdirAll(filepath.Join(repo, "TextMap"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "TextMap", "TextMapEN.json"), []byte(`{"1": "one"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "pinned")
+	git("tag", "v1")
+	// changes after the pinned tag must not be visible
+	if err := os.WriteFile(filepath.Join(repo, "TextMap", "TextMapEN.json"), []byte(`{"1": "uno"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("commit", "-q", "-am", "later")
+
+	source := data.NewGitSource(repo, "v1")
+	file, err := source.Open(context.Background(), "TextMap/TextMapEN.json", "")
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	body, err := io.ReadAll(file.Body)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if err := file.Body.Close(); err != nil {
+		t.Fatalf("Failed to close file: %v", err)
+	}
+	if string(body) != `{"1": "one"}` {
+		t.Errorf("Expected the pinned contents, got %s", body)
+	}
+
+	again, err := source.Open(context.Background(), "TextMap/TextMapEN.json", file.ETag)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	if !again.NotModified {
+		t.Error("Expected NotModified for the same ETag")
+	}
+
+	if _, err := source.Open(context.Background(), "TextMap/TextMapXX.json", ""); !errors.Is(err, data.ErrSourceFileNotFound) {
+		t.Errorf("Expected ErrSourceFileNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/client"
+)
+
+func newAccountServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	profile := loadFixtureProfile(t)
+	avatar, err := json.Marshal(profile.AvatarInfoList[0])
+	if err != nil {
+		t.Fatalf("Failed to encode avatar: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/profile/whisper/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"username": "whisper", "profile": {"bio": "hi", "level": 1, "signup_state": 3, "image_url": ""}, "id": 42}`))
+	})
+	mux.HandleFunc("/api/profile/whisper/hoyos/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"4Wjv2e": {"uid": 884846145, "uid_public": true, "public": true, "verified": true,
+			"player_info": {"nickname": "whisper", "level": 58}, "hash": "4Wjv2e", "region": "EU", "order": 0, "hoyo_type": 0}}`))
+	})
+	mux.HandleFunc("/api/profile/whisper/hoyos/4Wjv2e/builds/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"10000060": [{"id": 7, "name": "Main", "avatar_id": "10000060", "avatar_data": %s,
+			"order": 0, "live": false, "settings": {}, "public": true, "image": null, "hoyo_type": 0}]}`, avatar)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFetchAccount(t *testing.T) {
+	server := newAccountServer(t)
+	defer server.Close()
+	c := client.NewEnkaClient(client.WithBaseUrl(server.URL))
+
+	account, err := c.FetchAccount("whisper")
+	if err != nil {
+		t.Fatalf("Failed to fetch account: %v", err)
+	}
+	if account.ID != 42 || account.Profile.SignupState != 3 {
+		t.Errorf("Unexpected account %+v", account)
+	}
+
+	hoyos, err := c.FetchHoyos("whisper")
+	if err != nil {
+		t.Fatalf("Failed to fetch hoyos: %v", err)
+	}
+	hoyo, ok := hoyos["4Wjv2e"]
+	if !ok || hoyo.UID != 884846145 || hoyo.PlayerInfo.Nickname != "whisper" {
+		t.Errorf("Unexpected hoyos %+v", hoyos)
+	}
+
+	builds, err := c.FetchBuilds("whisper", hoyo.Hash)
+	if err != nil {
+		t.Fatalf("Failed to fetch builds: %v", err)
+	}
+	if len(builds[10000060]) != 1 {
+		t.Fatalf("Expected 1 build for avatar 10000060, got %d", len(builds[10000060]))
+	}
+	build := builds[10000060][0]
+	if build.Name != "Main" || build.AvatarData.Level() != 80 || len(build.AvatarData.EquipList) != 6 {
+		t.Errorf("Unexpected build %+v", build)
+	}
+}
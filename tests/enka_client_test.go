@@ -0,0 +1,193 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/utkarsh5026/Genka/src/client"
+)
+
+// newFixtureServer serves res/enka-uid-response.json for every /api/uid/ request
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile("../res/enka-uid-response.json")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/uid/884846145/" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("User-Agent") != "genka-tests" {
+			t.Errorf("Unexpected User-Agent %q", r.Header.Get("User-Agent"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestFetchProfile(t *testing.T) {
+	server := newFixtureServer(t)
+	defer server.Close()
+
+	c := client.NewEnkaClient(
+		client.WithBaseUrl(server.URL),
+		client.WithUserAgent("genka-tests"),
+		client.WithHTTPClient(server.Client()),
+	)
+
+	profile, err := c.FetchProfile("884846145")
+	if err != nil {
+		t.Fatalf("Failed to fetch profile: %v", err)
+	}
+
+	if profile.UID != "884846145" {
+		t.Errorf("Expected uid 884846145, got %s", profile.UID)
+	}
+	if profile.TTL != 60 {
+		t.Errorf("Expected ttl 60, got %d", profile.TTL)
+	}
+	if profile.PlayerInfo.Nickname != "whisper" {
+		t.Errorf("Expected nickname whisper, got %s", profile.PlayerInfo.Nickname)
+	}
+	if len(profile.AvatarInfoList) != 12 {
+		t.Errorf("Expected 12 avatars, got %d", len(profile.AvatarInfoList))
+	}
+}
+
+func TestFetchProfileUnknownUID(t *testing.T) {
+	server := newFixtureServer(t)
+	defer server.Close()
+
+	c := client.NewEnkaClient(client.WithBaseUrl(server.URL), client.WithUserAgent("genka-tests"))
+	if _, err := c.FetchProfile("100000000"); err == nil {
+		t.Fatal("Expected an error for an unknown uid")
+	}
+}
+
+// newCountingServer serves the fixture with the given ttl and counts the requests it receives
+func newCountingServer(t *testing.T, ttl string, hits *int32) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile("../res/enka-uid-response.json")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	body = []byte(strings.Replace(string(body), `"ttl": 60`, `"ttl": `+ttl, 1))
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestFetchProfileCache(t *testing.T) {
+	var hits int32
+	server := newCountingServer(t, "60", &hits)
+	defer server.Close()
+
+	c := client.NewEnkaClient(client.WithBaseUrl(server.URL))
+
+	var wg sync.WaitGroup
+	profiles := make([]*client.Profile, 10)
+	for i := range profiles {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			profile, err := c.FetchProfile("884846145")
+			if err != nil {
+				t.Errorf("Failed to fetch profile: %v", err)
+			}
+			profiles[i] = profile
+		}(i)
+	}
+	wg.Wait()
+
+	if _, err := c.FetchProfile("884846145"); err != nil {
+		t.Fatalf("Failed to fetch cached profile: %v", err)
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("Expected 1 request, got %d", hits)
+	}
+
+	expiresAt := profiles[0].ExpiresAt
+	if until := time.Until(expiresAt); until <= 0 || until > 60*time.Second {
+		t.Errorf("Expected ExpiresAt within 60s, got %v", expiresAt)
+	}
+}
+
+func TestFetchProfileCacheExpiry(t *testing.T) {
+	var hits int32
+	server := newCountingServer(t, "0", &hits)
+	defer server.Close()
+
+	c := client.NewEnkaClient(client.WithBaseUrl(server.URL))
+	for i := 0; i < 2; i++ {
+		if _, err := c.FetchProfile("884846145"); err != nil {
+			t.Fatalf("Failed to fetch profile: %v", err)
+		}
+	}
+	if atomic.LoadInt32(&hits) != 2 {
+		t.Errorf("Expected an expired profile to be fetched again, got %d requests", hits)
+	}
+}
+
+func TestFetchPlayerInfo(t *testing.T) {
+	var infoHits, fullHits int32
+	body, err := os.ReadFile("../res/enka-uid-response.json")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["info"]; ok {
+			atomic.AddInt32(&infoHits, 1)
+			_, _ = w.Write([]byte(`{"playerInfo": {"nickname": "whisper", "level": 58}, "ttl": 60, "uid": "884846145"}`))
+			return
+		}
+		atomic.AddInt32(&fullHits, 1)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	c := client.NewEnkaClient(client.WithBaseUrl(server.URL))
+
+	info, err := c.FetchPlayerInfo("884846145")
+	if err != nil {
+		t.Fatalf("Failed to fetch player info: %v", err)
+	}
+	if info.PlayerInfo.Nickname != "whisper" || info.PlayerInfo.Level != 58 {
+		t.Errorf("Unexpected player info %+v", info.PlayerInfo)
+	}
+	if info.ExpiresAt.IsZero() {
+		t.Error("Expected ExpiresAt to be set")
+	}
+	if _, err := c.FetchPlayerInfo("884846145"); err != nil {
+		t.Fatalf("Failed to fetch cached player info: %v", err)
+	}
+	if atomic.LoadInt32(&infoHits) != 1 {
+		t.Errorf("Expected 1 info request, got %d", infoHits)
+	}
+
+	// A cached full profile also answers info requests
+	if _, err := c.FetchProfile("111111111"); err != nil {
+		t.Fatalf("Failed to fetch profile: %v", err)
+	}
+	info, err = c.FetchPlayerInfo("111111111")
+	if err != nil {
+		t.Fatalf("Failed to fetch player info: %v", err)
+	}
+	if info.PlayerInfo.FinishAchievementNum != 629 {
+		t.Errorf("Expected player info from the cached profile, got %+v", info.PlayerInfo)
+	}
+	if atomic.LoadInt32(&infoHits) != 1 || atomic.LoadInt32(&fullHits) != 1 {
+		t.Errorf("Expected no extra requests, got %d info and %d full", infoHits, fullHits)
+	}
+}
@@ -0,0 +1,124 @@
+package data
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/utkarsh5026/Genka/src/client"
+)
+
+func TestFetchProfileErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, client.ErrInvalidUID},
+		{http.StatusNotFound, client.ErrPlayerNotFound},
+		{http.StatusFailedDependency, client.ErrMaintenance},
+		{http.StatusTooManyRequests, client.ErrRateLimited},
+		{http.StatusInternalServerError, client.ErrServerError},
+		{http.StatusServiceUnavailable, client.ErrServiceUnavailable},
+		{http.StatusTeapot, client.ErrUnexpectedStatus},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(tt.status)
+			_, _ = w.Write([]byte("<html>error</html>"))
+		}))
+
+		c := client.NewEnkaClient(client.WithBaseUrl(server.URL))
+		_, err := c.FetchProfile("884846145")
+		server.Close()
+
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Status %d: expected %v, got %v", tt.status, tt.want, err)
+		}
+		var apiErr *client.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("Status %d: expected an APIError, got %v", tt.status, err)
+		}
+	}
+}
+
+func TestFetchProfileRetry(t *testing.T) {
+	body, err := os.ReadFile("../res/enka-uid-response.json")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&hits, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewEnkaClient(
+		client.WithBaseUrl(server.URL),
+		client.WithRetry(client.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	)
+
+	start := time.Now()
+	if _, err := c.FetchProfile("884846145"); err != nil {
+		t.Fatalf("Expected the request to succeed after retrying, got %v", err)
+	}
+	if atomic.LoadInt32(&hits) != 3 {
+		t.Errorf("Expected 3 requests, got %d", hits)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected Retry-After to be honored, retried after %v", elapsed)
+	}
+}
+
+func TestFetchProfileNoRetryOnNotFound(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := client.NewEnkaClient(client.WithBaseUrl(server.URL), client.WithRetry(client.DefaultRetryPolicy))
+	if _, err := c.FetchProfile("884846145"); !errors.Is(err, client.ErrPlayerNotFound) {
+		t.Fatalf("Expected ErrPlayerNotFound, got %v", err)
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("Expected a 404 not to be retried, got %d requests", hits)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		policy  client.RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{client.DefaultRetryPolicy, 0, time.Second},
+		{client.DefaultRetryPolicy, 2, 4 * time.Second},
+		{client.DefaultRetryPolicy, 10, 30 * time.Second},
+		{client.DefaultRetryPolicy, 1000, 30 * time.Second},
+		{client.RetryPolicy{BaseDelay: time.Second}, 5, 32 * time.Second},
+		{client.RetryPolicy{BaseDelay: time.Second}, 40, client.MaxBackoff},
+		{client.RetryPolicy{BaseDelay: time.Second}, 63, client.MaxBackoff},
+		{client.RetryPolicy{BaseDelay: time.Second}, 1000, client.MaxBackoff},
+		{client.RetryPolicy{BaseDelay: 3 * time.Nanosecond}, 62, client.MaxBackoff},
+	} {
+		if got := tc.policy.Backoff(tc.attempt); got != tc.want {
+			t.Errorf("Backoff(%d) with %+v = %v, want %v", tc.attempt, tc.policy, got, tc.want)
+		}
+	}
+}
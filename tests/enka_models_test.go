@@ -0,0 +1,136 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/client"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+func loadFixtureProfile(t *testing.T) *client.Profile {
+	t.Helper()
+	body, err := os.ReadFile("../res/enka-uid-response.json")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	var profile client.Profile
+	if err := json.Unmarshal(body, &profile); err != nil {
+		t.Fatalf("Failed to decode fixture: %v", err)
+	}
+	return &profile
+}
+
+func TestProfileModels(t *testing.T) {
+	profile := loadFixtureProfile(t)
+
+	if len(profile.PlayerInfo.ShowAvatarInfoList) != 12 {
+		t.Errorf("Expected 12 showcased avatars, got %d", len(profile.PlayerInfo.ShowAvatarInfoList))
+	}
+	if profile.PlayerInfo.ProfilePicture.ID != 7500 {
+		t.Errorf("Expected profile picture 7500, got %d", profile.PlayerInfo.ProfilePicture.ID)
+	}
+
+	avatar := profile.AvatarInfoList[0]
+	if avatar.PropMap[4001].Val != "80" {
+		t.Errorf("Expected level prop 80, got %q", avatar.PropMap[4001].Val)
+	}
+	if avatar.FightPropMap[2000] != 30602.5859375 {
+		t.Errorf("Expected max HP 30602.5859375, got %v", avatar.FightPropMap[2000])
+	}
+	if avatar.SkillLevelMap[10610] != 8 {
+		t.Errorf("Expected skill 10610 at level 8, got %d", avatar.SkillLevelMap[10610])
+	}
+
+	var weapons, artifacts int
+	for _, equip := range avatar.EquipList {
+		if equip.IsWeapon() == equip.IsArtifact() {
+			t.Fatalf("Equipment %d must be either a weapon or an artifact", equip.ItemID)
+		}
+		if equip.IsWeapon() {
+			weapons++
+			if equip.Weapon.AffixMap[115401] != 0 {
+				t.Errorf("Expected refinement 0 for affix 115401, got %d", equip.Weapon.AffixMap[115401])
+			}
+			if len(equip.Flat.WeaponStats) != 2 {
+				t.Errorf("Expected 2 weapon stats, got %d", len(equip.Flat.WeaponStats))
+			}
+			continue
+		}
+		artifacts++
+		if equip.Flat.ReliquaryMainstat == nil {
+			t.Errorf("Artifact %d has no main stat", equip.ItemID)
+		}
+	}
+	if weapons != 1 || artifacts != 5 {
+		t.Errorf("Expected 1 weapon and 5 artifacts, got %d and %d", weapons, artifacts)
+	}
+
+	first := avatar.EquipList[0]
+	if first.Reliquary.Level != 21 || first.Reliquary.MainPropID != 14001 || len(first.Reliquary.AppendPropIDList) != 8 {
+		t.Errorf("Unexpected reliquary %+v", first.Reliquary)
+	}
+}
+
+func TestFightPropIDs(t *testing.T) {
+	profile := loadFixtureProfile(t)
+
+	for _, avatar := range profile.AvatarInfoList {
+		for id := range avatar.FightPropMap {
+			fp, ok := mapping.FightPropFromID(id)
+			if !ok {
+				t.Errorf("Fight prop ID %d of avatar %d is unknown", id, avatar.AvatarID)
+				continue
+			}
+			if back, _ := fp.ID(); back != id {
+				t.Errorf("Expected %s to map back to %d, got %d", fp, id, back)
+			}
+		}
+	}
+
+	if fp, ok := mapping.FightPropFromID(3046); !ok || mapping.FightPropMap[fp] != "Shield Cost Reduction" {
+		t.Errorf("Expected 3046 to be the shield cost reduction, got %s (%v)", fp, ok)
+	}
+	if _, ok := mapping.FightPropFromID(9999); ok {
+		t.Error("Expected an unknown fight prop ID not to resolve")
+	}
+
+	avatar := profile.AvatarInfoList[0]
+	if hp, ok := avatar.FightProp(mapping.FIGHT_PROP_MAX_HP); !ok || hp != 30602.5859375 {
+		t.Errorf("Expected max HP 30602.5859375, got %v (%v)", hp, ok)
+	}
+	if cr := avatar.FightProps()[mapping.FIGHT_PROP_CRITICAL]; cr != 0.5672000050544739 {
+		t.Errorf("Expected crit rate 0.5672000050544739, got %v", cr)
+	}
+}
+
+func TestPlayerProps(t *testing.T) {
+	profile := loadFixtureProfile(t)
+
+	avatar := profile.AvatarInfoList[0]
+	if avatar.Level() != 80 {
+		t.Errorf("Expected level 80, got %d", avatar.Level())
+	}
+	if avatar.Ascension() != 5 {
+		t.Errorf("Expected ascension 5, got %d", avatar.Ascension())
+	}
+	// 1001 only carries ival in the fixture
+	if exp, ok := avatar.Prop(mapping.PROP_EXP); !ok || exp != 0 {
+		t.Errorf("Expected experience 0, got %d (%v)", exp, ok)
+	}
+	if satiation, ok := avatar.Prop(mapping.PROP_SATIATION_VAL); !ok || satiation != 24000 {
+		t.Errorf("Expected satiation 24000, got %d (%v)", satiation, ok)
+	}
+
+	missing := client.AvatarInfo{PropMap: map[int]client.PropValue{
+		int(mapping.PROP_BREAK_LEVEL): {Type: int(mapping.PROP_BREAK_LEVEL)},
+	}}
+	if ascension, ok := missing.Prop(mapping.PROP_BREAK_LEVEL); !ok || ascension != 0 {
+		t.Errorf("Expected an entry without val and ival to be 0, got %d (%v)", ascension, ok)
+	}
+	if _, ok := missing.Prop(mapping.PROP_LEVEL); ok {
+		t.Error("Expected a missing entry to be reported")
+	}
+}
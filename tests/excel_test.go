@@ -0,0 +1,62 @@
+package data
+
+import (
+	"os"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/data"
+	"github.com/utkarsh5026/Genka/src/mapping"
+)
+
+func TestParseAvatarConfigs(t *testing.T) {
+	raw, err := os.ReadFile("../res/characters-detailed.json")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	avatars, err := data.ParseAvatarConfigs(raw)
+	if err != nil {
+		t.Fatalf("Failed to parse avatars: %v", err)
+	}
+	if len(avatars) != 122 {
+		t.Fatalf("Expected 122 avatars, got %d", len(avatars))
+	}
+
+	kate := avatars[0]
+	if kate.ID != 10000001 || kate.NameTextMapHash != 1857915418 || kate.SkillDepotID != 101 {
+		t.Errorf("Unexpected avatar %+v", kate)
+	}
+	if kate.HpBase != 166 || kate.CriticalHurt != 0.5 {
+		t.Errorf("Unexpected base stats %v / %v", kate.HpBase, kate.CriticalHurt)
+	}
+	if len(kate.PropGrowCurves) != 3 || kate.PropGrowCurves[0].Type != mapping.FIGHT_PROP_BASE_HP ||
+		kate.PropGrowCurves[0].GrowCurve != "GROW_CURVE_HP_S4" {
+		t.Errorf("Unexpected grow curves %+v", kate.PropGrowCurves)
+	}
+}
+
+func TestParseExcelRecords(t *testing.T) {
+	affixes, err := data.ParseReliquaryAffixes([]byte(`[
+		{"id": 501034, "depotId": 501, "groupId": 10, "propType": "FIGHT_PROP_HP_PERCENT", "propValue": 0.0583, "ABCDEFGHIJK": [1, 2]}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to parse affixes: %v", err)
+	}
+	if len(affixes) != 1 || affixes[0].PropType != mapping.FIGHT_PROP_HP_PERCENT || affixes[0].DepotID != 501 {
+		t.Errorf("Unexpected affixes %+v", affixes)
+	}
+
+	curves, err := data.ParseWeaponCurves([]byte(`[
+		{"level": 90, "curveInfos": [{"type": "GROW_CURVE_ATTACK_201", "arith": "ARITH_MULTI", "value": 10.2}]}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to parse curves: %v", err)
+	}
+	if curves[0].Level != 90 || curves[0].CurveInfos[0].Value != 10.2 {
+		t.Errorf("Unexpected curves %+v", curves)
+	}
+
+	if _, err := data.ParseAvatarPromotes([]byte(`{"not": "an array"}`)); err == nil {
+		t.Error("Expected an error for a malformed file")
+	}
+}
@@ -2,7 +2,10 @@
 package data
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/utkarsh5026/Genka/src/data"
@@ -55,3 +58,148 @@ func TestSaveDataFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordFiles(t *testing.T) {
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+
+	if _, err := fm.SaveDataFiles([]data.GenshinDataFileName{data.ArchonDataFile}, [][]byte{[]byte(`{"score": 300}`)}); err != nil {
+		t.Fatalf("Failed to save data files: %v", err)
+	}
+	manifest, err := fm.LoadManifest()
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	key := "data/" + string(data.ArchonDataFile) + ".json"
+	entry, ok := manifest.Files[key]
+	if !ok {
+		t.Fatalf("Entry %s missing from manifest", key)
+	}
+	if entry.Size != 14 || entry.SHA256 != "2166d9453ce502c920ed4178796d6d0fb9796675f95830645cc443f8f9bdfeac" {
+		t.Errorf("Unexpected size %d or checksum %s", entry.Size, entry.SHA256)
+	}
+
+	entry.Source, entry.Ref, entry.ETag = "https://example.com/archon", "master", `"etag-1"`
+	if err := fm.RecordFiles(entry); err != nil {
+		t.Fatalf("Failed to record files: %v", err)
+	}
+	manifest, err = fm.LoadManifest()
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	got := manifest.Files[key]
+	if got.SHA256 != entry.SHA256 || got.ETag != entry.ETag || got.Ref != "master" || !got.FetchedAt.Equal(entry.FetchedAt) {
+		t.Errorf("Manifest entry %+v does not match %+v", got, entry)
+	}
+}
+
+func TestLoadFileDetectsCorruption(t *testing.T) {
+	fm, err := data.NewFileManager()
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+
+	filePaths, err := fm.SaveDataFiles([]data.GenshinDataFileName{data.MaterialDataFile}, [][]byte{[]byte(`[{"id": 1}]`)})
+	if err != nil {
+		t.Fatalf("Failed to save data files: %v", err)
+	}
+	if _, err := fm.LoadFile(data.MaterialDataFile); err != nil {
+		t.Fatalf("Failed to load saved file: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(filePaths[data.MaterialDataFile]))
+	if err != nil {
+		t.Fatalf("Failed to list data directory: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("Temporary file %s left behind", entry.Name())
+		}
+	}
+
+	if err := os.WriteFile(filePaths[data.MaterialDataFile], []byte(`[{"id": 2`), 0644); err != nil {
+		t.Fatalf("Failed to truncate file: %v", err)
+	}
+	if _, err := fm.LoadFile(data.MaterialDataFile); !errors.Is(err, data.ErrCorruptFile) {
+		t.Errorf("Expected ErrCorruptFile, got %v", err)
+	}
+}
+
+func TestSaveModes(t *testing.T) {
+	fm, err := data.NewFileManager()
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+
+	fileNames := []data.GenshinDataFileName{data.RewardDataFile, data.ProfilePictureFile}
+	contents := [][]byte{[]byte(`[]`), []byte(`{broken`)}
+	for _, file := range fileNames {
+		_ = os.Remove(filepath.Join(filepath.Dir(fm.ManifestPath()), "data", string(file)+".json"))
+	}
+
+	fm.SetSaveMode(data.SaveAllOrNothing)
+	filePaths, err := fm.SaveDataFiles(fileNames, contents)
+	if err == nil {
+		t.Fatal("Expected an error for invalid JSON")
+	}
+	if len(filePaths) != 0 {
+		t.Errorf("Expected no saved files, got %v", filePaths)
+	}
+	if _, err := fm.LoadFile(data.RewardDataFile); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be missing, got %v", data.RewardDataFile, err)
+	}
+
+	fm.SetSaveMode(data.SaveIndependent)
+	filePaths, err = fm.SaveDataFiles(fileNames, contents)
+	if err == nil {
+		t.Fatal("Expected an error for invalid JSON")
+	}
+	if _, ok := filePaths[data.RewardDataFile]; !ok || len(filePaths) != 1 {
+		t.Errorf("Expected only %s to be saved, got %v", data.RewardDataFile, filePaths)
+	}
+}
+
+func TestFileManagerRoots(t *testing.T) {
+	if dir, err := data.DefaultDataDir(); err != nil || dir != os.Getenv(data.DataDirEnv) {
+		t.Errorf("Expected DefaultDataDir to honor %s, got %s (%v)", data.DataDirEnv, dir, err)
+	}
+
+	readOnly := t.TempDir()
+	baked, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: readOnly})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	fileNames := []data.GenshinDataFileName{data.WeaponDataFile, data.WeaponAscensionFile}
+	if _, err := baked.SaveDataFiles(fileNames, [][]byte{[]byte(`["baked"]`), []byte(`["baked"]`)}); err != nil {
+		t.Fatalf("Failed to save data files: %v", err)
+	}
+
+	writable := filepath.Join(t.TempDir(), "cache")
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: writable, ReadOnlyRoots: []string{readOnly}})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	if fm.Root() != writable {
+		t.Errorf("Expected root %s, got %s", writable, fm.Root())
+	}
+
+	got, err := fm.LoadFile(data.WeaponDataFile)
+	if err != nil || string(got) != `["baked"]` {
+		t.Errorf("Expected the read-only copy, got %s (%v)", got, err)
+	}
+
+	if _, err := fm.SaveDataFiles(fileNames[1:], [][]byte{[]byte(`["fresh"]`)}); err != nil {
+		t.Fatalf("Failed to save data files: %v", err)
+	}
+	if got, err := fm.LoadFile(data.WeaponAscensionFile); err != nil || string(got) != `["fresh"]` {
+		t.Errorf("Expected the writable copy to take precedence, got %s (%v)", got, err)
+	}
+	if got, err := baked.LoadFile(data.WeaponAscensionFile); err != nil || string(got) != `["baked"]` {
+		t.Errorf("Expected the read-only root to be untouched, got %s (%v)", got, err)
+	}
+	if _, err := fm.LoadFile(data.MaterialDataFile); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/data"
+)
+
+// TestMain points the default storage root of every FileManager created by
+// the tests at a temporary directory.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "genka-test-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv(data.DataDirEnv, dir)
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
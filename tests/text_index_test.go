@@ -0,0 +1,196 @@
+package data
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/data"
+)
+
+func TestTextIndex(t *testing.T) {
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	_, err = fm.SaveLangFiles(
+		[]data.Language{data.LangEnglish, data.LangJapanese, data.LangFrench},
+		[][]byte{
+			[]byte(`{"1857915418": "Traveler", "1240067179": "Zephyrus"}`),
+			[]byte(`{"1857915418": "旅人"}`),
+			[]byte(`{"1857915418": "Voyageur"}`),
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+
+	index := data.NewTextIndex(fm, 2)
+	if loaded := index.Loaded(); len(loaded) != 0 {
+		t.Errorf("Expected no language before the first lookup, got %v", loaded)
+	}
+
+	for _, lookup := range []struct {
+		lang data.Language
+		want string
+	}{
+		{data.LangEnglish, "Traveler"},
+		{data.LangJapanese, "旅人"},
+		{data.LangEnglish, "Traveler"},
+		{data.LangFrench, "Voyageur"},
+	} {
+		if text, err := index.Lookup(lookup.lang, 1857915418); err != nil || text != lookup.want {
+			t.Errorf("Expected %q in %s, got %q (%v)", lookup.want, lookup.lang, text, err)
+		}
+	}
+	want := []data.Language{data.LangFrench, data.LangEnglish}
+	if loaded := index.Loaded(); !reflect.DeepEqual(loaded, want) {
+		t.Errorf("Expected the least recently used language to be evicted, got %v", loaded)
+	}
+
+	if _, err := index.Lookup(data.LangFrench, 1240067179); !errors.Is(err, data.ErrTextNotFound) {
+		t.Errorf("Expected ErrTextNotFound, got %v", err)
+	}
+
+	index.Unload(data.LangFrench)
+	if text, err := index.Lookup(data.LangJapanese, 1857915418); err != nil || text != "旅人" {
+		t.Errorf("Expected an evicted language to load again, got %q (%v)", text, err)
+	}
+	want = []data.Language{data.LangJapanese, data.LangEnglish}
+	if loaded := index.Loaded(); !reflect.DeepEqual(loaded, want) {
+		t.Errorf("Expected %v, got %v", want, loaded)
+	}
+}
+
+func TestTextIndexLoadError(t *testing.T) {
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	index := data.NewTextIndex(fm, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := index.Lookup(data.LangKorean, 1); err == nil || errors.Is(err, data.ErrTextNotFound) {
+				t.Errorf("Expected a load error for a missing TextMap, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if loaded := index.Loaded(); len(loaded) != 0 {
+		t.Errorf("Expected a failed language not to be kept, got %v", loaded)
+	}
+
+	if _, err := fm.SaveLangFiles([]data.Language{data.LangKorean}, [][]byte{[]byte(`{"1": "여행자"}`)}); err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+	if text, err := index.Lookup(data.LangKorean, 1); err != nil || text != "여행자" {
+		t.Errorf("Expected the TextMap to load once saved, got %q (%v)", text, err)
+	}
+}
+
+func TestTextResolverSharedIndex(t *testing.T) {
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	_, err = fm.SaveLangFiles(
+		[]data.Language{data.LangEnglish, data.LangGerman},
+		[][]byte{
+			[]byte(`{"1857915418": "Traveler", "1240067179": "Zephyrus"}`),
+			[]byte(`{"1857915418": "Reisender"}`),
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+
+	index := data.NewTextIndex(fm, 2)
+	german := data.NewTextResolverWithIndex(index, data.LangGerman, data.LangEnglish)
+	english := data.NewTextResolverWithIndex(index, data.LangEnglish)
+
+	if text, err := german.ResolveHash(1240067179); err != nil || text != "Zephyrus" {
+		t.Errorf("Expected the fallback text Zephyrus, got %q (%v)", text, err)
+	}
+	if text, err := english.Resolve("1857915418"); err != nil || text != "Traveler" {
+		t.Errorf("Expected Traveler, got %q (%v)", text, err)
+	}
+	if loaded := index.Loaded(); len(loaded) != 2 {
+		t.Errorf("Expected the resolvers to share two languages, got %v", loaded)
+	}
+}
+
+func TestTextIndexPruned(t *testing.T) {
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	_, err = fm.SaveDataFiles([]data.GenshinDataFileName{data.CharacterDataFile}, [][]byte{[]byte(`[{"nameTextMapHash": 1001}]`)})
+	if err != nil {
+		t.Fatalf("Failed to save data files: %v", err)
+	}
+	textMap := [][]byte{[]byte(`{"1001": "Traveler", "1002": "Paimon"}`)}
+	if _, err := fm.SaveLangFiles([]data.Language{data.LangEnglish}, textMap); err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+	hashes, err := fm.TextMapHashes()
+	if err != nil {
+		t.Fatalf("Failed to collect hashes: %v", err)
+	}
+	if err := fm.PruneLangFiles([]data.Language{data.LangEnglish}, hashes, data.TextMapBinary); err != nil {
+		t.Fatalf("Failed to prune lang files: %v", err)
+	}
+
+	index := data.NewTextIndex(fm, 0)
+	if text, err := index.Lookup(data.LangEnglish, 1001); err != nil || text != "Traveler" {
+		t.Errorf("Expected Traveler, got %q (%v)", text, err)
+	}
+	if loaded := index.Loaded(); len(loaded) != 1 {
+		t.Errorf("Expected the pruned TextMap to be loaded, got %v", loaded)
+	}
+	if text, err := index.Lookup(data.LangEnglish, 1002); err != nil || text != "Paimon" {
+		t.Errorf("Expected a hash outside the pruned TextMap to come from the full one, got %q (%v)", text, err)
+	}
+	if _, err := index.Lookup(data.LangEnglish, 1003); !errors.Is(err, data.ErrTextNotFound) {
+		t.Errorf("Expected ErrTextNotFound, got %v", err)
+	}
+
+	// a resolver falls back to the next language only when neither TextMap has the hash
+	_, err = fm.SaveLangFiles([]data.Language{data.LangFrench}, [][]byte{[]byte(`{"1002": "Paimon (FR)", "1003": "Venti"}`)})
+	if err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+	resolver := data.NewTextResolverWithIndex(index, data.LangEnglish, data.LangFrench)
+	for hash, want := range map[uint32]string{1001: "Traveler", 1002: "Paimon", 1003: "Venti"} {
+		if text, err := resolver.ResolveHash(hash); err != nil || text != want {
+			t.Errorf("Expected %q for hash %d, got %q (%v)", want, hash, text, err)
+		}
+	}
+
+	// without the full TextMap only the pruned hashes resolve
+	if err := os.Remove(filepath.Join(fm.Root(), "langs", string(data.LangEnglish)+".json")); err != nil {
+		t.Fatalf("Failed to remove the full TextMap: %v", err)
+	}
+	index = data.NewTextIndex(fm, 0)
+	if text, err := index.Lookup(data.LangEnglish, 1001); err != nil || text != "Traveler" {
+		t.Errorf("Expected Traveler, got %q (%v)", text, err)
+	}
+	if _, err := index.Lookup(data.LangEnglish, 1002); !errors.Is(err, data.ErrTextNotFound) {
+		t.Errorf("Expected ErrTextNotFound without the full TextMap, got %v", err)
+	}
+
+	// a TextMap saved after pruning makes the pruned copy stale
+	if _, err := fm.SaveLangFiles([]data.Language{data.LangEnglish}, textMap); err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+	index.Unload(data.LangEnglish)
+	if text, err := index.Lookup(data.LangEnglish, 1002); err != nil || text != "Paimon" {
+		t.Errorf("Expected the full TextMap to be used, got %q (%v)", text, err)
+	}
+}
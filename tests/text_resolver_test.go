@@ -0,0 +1,66 @@
+package data
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/data"
+)
+
+func TestTextResolver(t *testing.T) {
+	fm, err := data.NewFileManager()
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+
+	_, err = fm.SaveLangFiles(
+		[]data.Language{data.LangJapanese, data.LangEnglish},
+		[][]byte{
+			[]byte(`{"1857915418": "旅人"}`),
+			[]byte(`{"1857915418": "Traveler", "1240067179": "Zephyrus"}`),
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+
+	tr := data.NewTextResolver(fm, data.LangJapanese, data.LangEnglish)
+
+	if text, err := tr.ResolveHash(1857915418); err != nil || text != "旅人" {
+		t.Errorf("Expected 旅人, got %q (%v)", text, err)
+	}
+	if text, err := tr.Resolve("1240067179"); err != nil || text != "Zephyrus" {
+		t.Errorf("Expected the fallback text Zephyrus, got %q (%v)", text, err)
+	}
+	if _, err := tr.Resolve("42"); !errors.Is(err, data.ErrTextNotFound) {
+		t.Errorf("Expected ErrTextNotFound, got %v", err)
+	}
+	if _, err := tr.Resolve("not-a-hash"); err == nil {
+		t.Error("Expected an error for a malformed hash")
+	}
+}
+
+func TestTextResolverMissingLanguage(t *testing.T) {
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	_, err = fm.SaveLangFiles([]data.Language{data.LangEnglish}, [][]byte{[]byte(`{"1857915418": "Traveler"}`)})
+	if err != nil {
+		t.Fatalf("Failed to save language files: %v", err)
+	}
+
+	tr := data.NewTextResolver(fm, data.LangJapanese, data.LangEnglish)
+	if text, err := tr.ResolveHash(1857915418); err != nil || text != "Traveler" {
+		t.Errorf("Expected the fallback text Traveler, got %q (%v)", text, err)
+	}
+	if _, err := tr.ResolveHash(42); !errors.Is(err, data.ErrTextNotFound) {
+		t.Errorf("Expected ErrTextNotFound, got %v", err)
+	}
+
+	tr = data.NewTextResolver(fm, data.LangJapanese, data.LangKorean)
+	if _, err := tr.ResolveHash(1857915418); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected a not exist error when no TextMap is on disk, got %v", err)
+	}
+}
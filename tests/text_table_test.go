@@ -0,0 +1,154 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/utkarsh5026/Genka/src/data"
+)
+
+func TestTextTable(t *testing.T) {
+	table := data.NewTextTable(map[uint32]string{
+		1001:       "Traveler",
+		7:          "",
+		4294967295: "Paimon",
+	})
+
+	var buf bytes.Buffer
+	if _, err := table.WriteTo(&buf); err != nil {
+		t.Fatalf("Failed to write table: %v", err)
+	}
+	decoded, err := data.DecodeTextTable(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to decode table: %v", err)
+	}
+	if decoded.Len() != 3 {
+		t.Errorf("Expected 3 texts, got %d", decoded.Len())
+	}
+	for hash, want := range map[uint32]string{1001: "Traveler", 7: "", 4294967295: "Paimon"} {
+		if got, ok := decoded.Lookup(hash); !ok || got != want {
+			t.Errorf("Lookup(%d) = %q, %v, want %q", hash, got, ok, want)
+		}
+	}
+	if _, ok := decoded.Lookup(1002); ok {
+		t.Error("Expected no text for an unknown hash")
+	}
+
+	raw := buf.Bytes()
+	if _, err := data.DecodeTextTable(raw[:len(raw)-1]); !errors.Is(err, data.ErrInvalidTextTable) {
+		t.Errorf("Expected ErrInvalidTextTable for truncated texts, got %v", err)
+	}
+	if _, err := data.DecodeTextTable([]byte(`{"1001": "Traveler"}`)); !errors.Is(err, data.ErrInvalidTextTable) {
+		t.Errorf("Expected ErrInvalidTextTable for JSON, got %v", err)
+	}
+}
+
+func TestReadTextMap(t *testing.T) {
+	raw := `{"1001": "Traveler", "1002": "Paimon", "notahash": "skipped", "99999999999": "too large"}`
+	textMap, err := data.ReadTextMap(strings.NewReader(raw), func(hash uint32) bool { return hash == 1002 })
+	if err != nil {
+		t.Fatalf("Failed to read TextMap: %v", err)
+	}
+	if len(textMap) != 1 || textMap[1002] != "Paimon" {
+		t.Errorf("Unexpected TextMap %v", textMap)
+	}
+
+	if _, err := data.ReadTextMap(strings.NewReader(`{"1001": "truncated`), nil); err == nil {
+		t.Error("Expected an error for a truncated TextMap")
+	}
+}
+
+func TestPruneLangFiles(t *testing.T) {
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+
+	_, err = fm.SaveDataFiles(
+		[]data.GenshinDataFileName{data.CharacterDataFile},
+		[][]byte{[]byte(`[{"id": 1, "nameTextMapHash": 1001, "skill": {"descTextMapHash": "1003"}, "iconHash": 1004}]`)},
+	)
+	if err != nil {
+		t.Fatalf("Failed to save data files: %v", err)
+	}
+	_, err = fm.SaveLangFiles(
+		[]data.Language{data.LangEnglish},
+		[][]byte{[]byte(`{"1001": "Traveler", "1002": "Paimon", "1003": "Wind", "1004": "unused"}`)},
+	)
+	if err != nil {
+		t.Fatalf("Failed to save lang files: %v", err)
+	}
+
+	hashes, err := fm.TextMapHashes()
+	if err != nil {
+		t.Fatalf("Failed to collect hashes: %v", err)
+	}
+	if len(hashes) != 2 {
+		t.Errorf("Expected hashes 1001 and 1003, got %v", hashes)
+	}
+
+	prunedDir := filepath.Join(fm.Root(), "langs", "pruned")
+	for _, format := range []data.TextMapFormat{data.TextMapJSON, data.TextMapBinary} {
+		if err := fm.PruneLangFiles([]data.Language{data.LangEnglish}, hashes, format); err != nil {
+			t.Fatalf("Failed to prune lang files: %v", err)
+		}
+		table, err := fm.LoadPrunedLangFile(data.LangEnglish)
+		if err != nil {
+			t.Fatalf("Failed to load pruned lang file: %v", err)
+		}
+		if got, _ := table.Lookup(1003); table.Len() != 2 || got != "Wind" {
+			t.Errorf("Unexpected pruned table for format %d: %d texts, 1003 = %q", format, table.Len(), got)
+		}
+
+		entries, err := os.ReadDir(prunedDir)
+		if err != nil {
+			t.Fatalf("Failed to list pruned directory: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("Expected one pruned file for format %d, got %d", format, len(entries))
+		}
+	}
+
+	if _, err := fm.LoadPrunedLangFile(data.LangFrench); !os.IsNotExist(err) {
+		t.Errorf("Expected a not exist error for an unpruned language, got %v", err)
+	}
+}
+
+func TestLoaderTextMapPruning(t *testing.T) {
+	var downloads int32
+	server := newDataServer(t, map[string]string{
+		"/master/ExcelBinOutput/AvatarExcelConfigData.json": `[{"nameTextMapHash": 1001}]`,
+		"/master/TextMap/TextMapEN.json":                    `{"1001": "Traveler", "1002": "Paimon"}`,
+	}, &downloads)
+
+	fm, err := data.NewFileManagerWithOptions(data.FileManagerOptions{Root: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Failed to create FileManager: %v", err)
+	}
+	rl := data.NewResourceLoader(fm, false,
+		data.WithSource(data.NewHTTPSource(server.URL+"/{ref}/{path}", "master")),
+		data.WithTextMapPruning(data.TextMapBinary),
+	)
+
+	if err := rl.LoadDataFiles([]data.GenshinDataFileName{data.CharacterDataFile}); err != nil {
+		t.Fatalf("Failed to load data files: %v", err)
+	}
+	if err := rl.LoadLangFiles([]data.Language{data.LangEnglish}); err != nil {
+		t.Fatalf("Failed to load lang files: %v", err)
+	}
+
+	table, err := fm.LoadPrunedLangFile(data.LangEnglish)
+	if err != nil {
+		t.Fatalf("Failed to load pruned lang file: %v", err)
+	}
+	if got, _ := table.Lookup(1001); table.Len() != 1 || got != "Traveler" {
+		t.Errorf("Unexpected pruned table: %d texts, 1001 = %q", table.Len(), got)
+	}
+	if full, err := fm.LoadLangFile(data.LangEnglish); err != nil || !strings.Contains(string(full), "Paimon") {
+		t.Errorf("Expected the full TextMap to be kept, got %s: %v", full, err)
+	}
+}